@@ -1,12 +1,14 @@
 package collector
 
 import (
+	"strconv"
 	"sync"
 	"strings"
 
 	"github.com/apex/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/magicst0ne/rackserver_exporter/redfish"
+	redfishcommon "github.com/magicst0ne/rackserver_exporter/redfish/common"
 	"github.com/magicst0ne/rackserver_exporter/redfish/redfishapi"
 )
 
@@ -17,6 +19,7 @@ var (
 	ChassisTemperatureLabelNames      = []string{"sn", "mfr","resource", "chassis_id", "sensor", "sensor_id"}
 	ChassisFanLabelNames              = []string{"sn", "mfr","resource", "chassis_id", "fan", "fan_id"}
 	ChassisPowerSupplyLabelNames      = []string{"sn", "mfr","resource", "chassis_id", "power_supply", "power_supply_id"}
+	ChassisLocationLabelNames         = []string{"sn", "mfr","resource", "chassis_id", "rack", "row", "rack_offset", "rack_offset_units", "slot"}
 
 	chassisMetrics = map[string]chassisMetric{
 		"chassis_health": {
@@ -35,6 +38,30 @@ var (
 				nil,
 			),
 		},
+		"chassis_intrusion_sensor": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, ChassisSubsystem, "intrusion_sensor"),
+				"physical security sensor of chassis,1(Normal),2(HardwareIntrusion),3(TamperingDetected)",
+				ChassisLabelNames,
+				nil,
+			),
+		},
+		"chassis_indicator_led": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, ChassisSubsystem, "indicator_led"),
+				"locate indicator led of chassis,1(Off),2(Lit),3(Blinking),4(Unknown)",
+				ChassisLabelNames,
+				nil,
+			),
+		},
+		"chassis_location_info": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, ChassisSubsystem, "location_info"),
+				"physical location of chassis, value is always 1",
+				ChassisLocationLabelNames,
+				nil,
+			),
+		},
 		"chassis_temperature_sensor_state": {
 			desc: prometheus.NewDesc(
 				prometheus.BuildFQName(namespace, ChassisSubsystem, "temperature_sensor_state"),
@@ -75,6 +102,38 @@ var (
 				nil,
 			),
 		},
+		"chassis_fan_speed_rpm": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, ChassisSubsystem, "fan_speed_rpm"),
+				"fan speed in rpm on this chassis component",
+				ChassisFanLabelNames,
+				nil,
+			),
+		},
+		"chassis_fan_speed_percent": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, ChassisSubsystem, "fan_speed_percent"),
+				"fan speed in percent of its maximum on this chassis component",
+				ChassisFanLabelNames,
+				nil,
+			),
+		},
+		"chassis_environment_power_watts": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, ChassisSubsystem, "environment_power_watts"),
+				"power consumed by chassis from its environment metrics, Watts",
+				ChassisLabelNames,
+				nil,
+			),
+		},
+		"chassis_environment_temperature_celsius": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, ChassisSubsystem, "environment_temperature_celsius"),
+				"temperature of chassis from its environment metrics, Celsius",
+				ChassisLabelNames,
+				nil,
+			),
+		},
 		"chassis_power_powersupply_state": {
 			desc: prometheus.NewDesc(
 				prometheus.BuildFQName(namespace, ChassisSubsystem, "power_powersupply_state"),
@@ -115,6 +174,8 @@ type ChassisCollector struct {
 	redfishClient         *redfish.APIClient
 	metrics               map[string]chassisMetric
 	collectorScrapeStatus *prometheus.GaugeVec
+	health                *TargetHealth
+	tier                  string
 	Log                   *log.Entry
 }
 
@@ -122,15 +183,20 @@ type chassisMetric struct {
 	desc *prometheus.Desc
 }
 
-// NewChassisCollector returns a collector that collecting chassis statistics
-func NewChassisCollector(namespace string, redfishClient *redfish.APIClient, logger *log.Entry) *ChassisCollector {
+// NewChassisCollector returns a collector that collecting chassis statistics.
+// It collects the states and sensor readings of the chassis for the readings
+// tier, and their location and power supply capacities for the inventory tier.
+func NewChassisCollector(namespace string, redfishClient *redfish.APIClient, health *TargetHealth, tier string, logger *log.Entry) *ChassisCollector {
 	// get service from redfish client
 
 	return &ChassisCollector{
 		redfishClient: redfishClient,
 		metrics:       chassisMetrics,
+		health:        health,
+		tier:          tier,
 		Log: logger.WithFields(log.Fields{
 			"collector": "ChassisCollector",
+			"tier":      tier,
 		}),
 		collectorScrapeStatus: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
@@ -166,17 +232,8 @@ func (c *ChassisCollector) Collect(ch chan<- prometheus.Metric) {
 			chassisLogContext := collectorLogContext.WithField("Chassis", chassis.ID)
 			chassisLogContext.Info("collector scrape started")
 
-			SerialNumber := chassis.SerialNumber
-
-			systemManufacturer := "Unknown"
-			if chassis.Manufacturer != "" {
-				tmpStr := strings.Split(chassis.Manufacturer, " ")
-				systemManufacturer = tmpStr[0]
-			}
-
-			if systemManufacturer=="Dell" {
-				SerialNumber = chassis.SKU
-			}
+			SerialNumber := chassisSerialNumber(chassis)
+			systemManufacturer := chassisManufacturer(chassis)
 
 			chassisID := chassis.ID
 			chassisStatus := chassis.Status
@@ -184,6 +241,18 @@ func (c *ChassisCollector) Collect(ch chan<- prometheus.Metric) {
 			chassisStatusHealth := chassisStatus.Health
 			ChassisLabelValues := []string{SerialNumber, systemManufacturer, "chassis", chassisID}
 
+			if c.tier == InventoryTier {
+				if chassisLocationLabelValues, ok := chassisLocation(chassis.Location); ok {
+					chassisLocationLabelValues = append([]string{SerialNumber, systemManufacturer, "chassis", chassisID}, chassisLocationLabelValues...)
+					ch <- prometheus.MustNewConstMetric(c.metrics["chassis_location_info"].desc, prometheus.GaugeValue, float64(1), chassisLocationLabelValues...)
+				}
+				c.collectPower(ch, chassis, SerialNumber, systemManufacturer, chassisLogContext)
+				chassisLogContext.Info("collector scrape completed")
+				continue
+			}
+
+			c.health.Observe("chassis", chassisID, chassisID, chassisStatusHealth)
+
 			if chassisStatusHealthValue, ok := parseCommonStatusHealth(chassisStatusHealth); ok {
 				ch <- prometheus.MustNewConstMetric(c.metrics["chassis_health"].desc, prometheus.GaugeValue, chassisStatusHealthValue, ChassisLabelValues...)
 			}
@@ -191,51 +260,320 @@ func (c *ChassisCollector) Collect(ch chan<- prometheus.Metric) {
 				ch <- prometheus.MustNewConstMetric(c.metrics["chassis_state"].desc, prometheus.GaugeValue, chassisStatusStateValue, ChassisLabelValues...)
 			}
 
-			chassisThermal, err := chassis.Thermal()
-			if err != nil {
-				chassisLogContext.WithField("operation", "chassis.Thermal()").WithError(err).Error("error getting thermal data from chassis")
-			} else if chassisThermal == nil {
-				chassisLogContext.WithField("operation", "chassis.Thermal()").Info("no thermal data found")
-			} else {
-				// process temperature
-				chassisTemperatures := chassisThermal.Temperatures
-				wg := &sync.WaitGroup{}
-				wg.Add(len(chassisTemperatures))
-
-				for _, chassisTemperature := range chassisTemperatures {
-					go parseChassisTemperature(ch, SerialNumber, systemManufacturer, chassisID, chassisTemperature, wg)
-				}
+			if chassisIntrusionSensorValue, ok := parseIntrusionSensor(chassis.PhysicalSecurity.IntrusionSensor); ok {
+				ch <- prometheus.MustNewConstMetric(c.metrics["chassis_intrusion_sensor"].desc, prometheus.GaugeValue, chassisIntrusionSensorValue, ChassisLabelValues...)
+			}
+			if chassisIndicatorLEDValue, ok := parseIndicatorLED(chassis.LocatorLED()); ok {
+				ch <- prometheus.MustNewConstMetric(c.metrics["chassis_indicator_led"].desc, prometheus.GaugeValue, chassisIndicatorLEDValue, ChassisLabelValues...)
+			}
 
-				// process fans
-				chassisFans := chassisThermal.Fans
-				wg2 := &sync.WaitGroup{}
-				wg2.Add(len(chassisFans))
-				for _, chassisFan := range chassisFans {
-					go parseChassisFan(ch, SerialNumber, systemManufacturer, chassisID, chassisFan, wg2)
-				}
+			c.collectThermal(ch, chassis, SerialNumber, systemManufacturer, chassisLogContext)
+			c.collectEnvironment(ch, chassis, ChassisLabelValues, chassisLogContext)
+			c.collectPower(ch, chassis, SerialNumber, systemManufacturer, chassisLogContext)
+			chassisLogContext.Info("collector scrape completed")
+
+		}
+	}
+
+	c.collectorScrapeStatus.WithLabelValues("chassis").Set(float64(1))
+}
+
+// chassisManufacturer returns the first word of the manufacturer of chassis.
+func chassisManufacturer(chassis *redfishapi.Chassis) string {
+	if chassis.Manufacturer == "" {
+		return "Unknown"
+	}
+	return strings.Split(chassis.Manufacturer, " ")[0]
+}
+
+// chassisSerialNumber returns the serial number of chassis, which is the
+// service tag (SKU) for Dell.
+func chassisSerialNumber(chassis *redfishapi.Chassis) string {
+	if chassisManufacturer(chassis) == "Dell" {
+		return chassis.SKU
+	}
+	return chassis.SerialNumber
+}
+
+func parseIntrusionSensor(sensor redfishapi.IntrusionSensor) (float64, bool) {
+	switch sensor {
+	case redfishapi.NormalIntrusionSensor:
+		return float64(1), true
+	case redfishapi.HardwareIntrusionIntrusionSensor:
+		return float64(2), true
+	case redfishapi.TamperingDetectedIntrusionSensor:
+		return float64(3), true
+	}
+	return float64(0), false
+}
+
+func parseIndicatorLED(led redfishcommon.IndicatorLED) (float64, bool) {
+	switch led {
+	case redfishcommon.OffIndicatorLED:
+		return float64(1), true
+	case redfishcommon.LitIndicatorLED:
+		return float64(2), true
+	case redfishcommon.BlinkingIndicatorLED:
+		return float64(3), true
+	case redfishcommon.UnknownIndicatorLED:
+		return float64(4), true
+	}
+	return float64(0), false
+}
+
+// chassisLocation returns the rack, row, rack offset, its units and slot of
+// location, or false if the service does not report a placement. The units
+// are empty if the service reports the offset only.
+func chassisLocation(location redfishcommon.Location) ([]string, bool) {
+	placement := location.Placement
+	partLocation := location.PartLocation
+
+	rackOffset := ""
+	if placement.RackOffset != nil {
+		rackOffset = strconv.Itoa(*placement.RackOffset)
+	}
+
+	slot := partLocation.ServiceLabel
+	if slot == "" && partLocation.LocationType == redfishcommon.SlotLocationType {
+		slot = strconv.Itoa(partLocation.LocationOrdinalValue)
+	}
+
+	if placement.Rack == "" && placement.Row == "" && rackOffset == "" && slot == "" {
+		return nil, false
+	}
+	return []string{placement.Rack, placement.Row, rackOffset, string(placement.RackOffsetUnits), slot}, true
+}
+
+// collectThermal collects the temperatures and fans from the ThermalSubsystem
+// and Sensors of the chassis, falling back to the deprecated Thermal resource
+// for whatever they do not provide. The Sensors are only read if the service
+// expands them, they would take a request per sensor otherwise. The readings
+// keep the ids of the Thermal resource while the service provides it, so
+// their series don't change with the firmware.
+func (c *ChassisCollector) collectThermal(ch chan<- prometheus.Metric, chassis *redfishapi.Chassis, SerialNumber, systemManufacturer string, chassisLogContext *log.Entry) {
+	chassisID := chassis.ID
+
+	var chassisTemperatures []redfishapi.Temperature
+	sensors, _, err := chassis.ExpandedSensors()
+	if err != nil {
+		chassisLogContext.WithField("operation", "chassis.Sensors()").WithError(err).Error("error getting sensors from chassis")
+	}
+	for _, sensor := range sensors {
+		if sensor.ReadingType == redfishapi.TemperatureReadingType {
+			chassisTemperatures = append(chassisTemperatures, temperatureFromSensor(sensor))
+		}
+	}
+
+	var chassisFans []redfishapi.Fan
+	thermalSubsystem, err := chassis.ThermalSubsystem()
+	if err != nil {
+		chassisLogContext.WithField("operation", "chassis.ThermalSubsystem()").WithError(err).Error("error getting thermal subsystem from chassis")
+	} else if thermalSubsystem != nil {
+		fans, err := thermalSubsystem.Fans()
+		if err != nil {
+			chassisLogContext.WithField("operation", "thermalSubsystem.Fans()").WithError(err).Error("error getting fans from thermal subsystem")
+		}
+		for _, fan := range fans {
+			chassisFans = append(chassisFans, fanFromCoolingFan(fan))
+		}
+	}
+
+	legacyFans := false
+	chassisThermal, err := chassis.Thermal()
+	if err != nil {
+		chassisLogContext.WithField("operation", "chassis.Thermal()").WithError(err).Error("error getting thermal data from chassis")
+	} else if chassisThermal == nil {
+		chassisLogContext.WithField("operation", "chassis.Thermal()").Info("no thermal data found")
+	} else {
+		if len(chassisTemperatures) == 0 {
+			chassisTemperatures = chassisThermal.Temperatures
+		} else {
+			temperatureIDs := thermalMemberIDs{}
+			for _, temperature := range chassisThermal.Temperatures {
+				temperatureIDs.add(temperature.DataSourceURI, temperature.Name, temperature.MemberID)
+			}
+			for i := range chassisTemperatures {
+				chassisTemperatures[i].MemberID = temperatureIDs.get(chassisTemperatures[i].ODataID, chassisTemperatures[i].Name, chassisTemperatures[i].MemberID)
+			}
+		}
+
+		if len(chassisFans) == 0 {
+			chassisFans = chassisThermal.Fans
+			legacyFans = true
+		} else {
+			fanIDs := thermalMemberIDs{}
+			for _, fan := range chassisThermal.Fans {
+				fanIDs.add(fan.DataSourceURI, fan.Name, fan.MemberID)
+			}
+			for i := range chassisFans {
+				chassisFans[i].MemberID = fanIDs.get(chassisFans[i].ODataID, chassisFans[i].Name, chassisFans[i].MemberID)
 			}
+		}
+	}
 
+	// process temperature
+	wg := &sync.WaitGroup{}
+	wg.Add(len(chassisTemperatures))
+	for _, chassisTemperature := range chassisTemperatures {
+		go parseChassisTemperature(ch, SerialNumber, systemManufacturer, chassisID, chassisTemperature, wg)
+	}
+
+	// process fans
+	wg2 := &sync.WaitGroup{}
+	wg2.Add(len(chassisFans))
+	for _, chassisFan := range chassisFans {
+		c.health.Observe("fan", chassisID, chassisFan.Name, chassisFan.Status.Health)
+		go parseChassisFan(ch, SerialNumber, systemManufacturer, chassisID, chassisFan, legacyFans, wg2)
+	}
 
-			chassisPowerInfo, err := chassis.Power()
-			if err != nil {
-				chassisLogContext.WithField("operation", "chassis.Power()").WithError(err).Error("error getting power data from chassis")
-			} else if chassisPowerInfo == nil {
-				chassisLogContext.WithField("operation", "chassis.Power()").Info("no power data found")
-			} else {
-				// powerSupply
-				chassisPowerInfoPowerSupplies := chassisPowerInfo.PowerSupplies
-				wg5 := &sync.WaitGroup{}
-				wg5.Add(len(chassisPowerInfoPowerSupplies))
-				for _, chassisPowerInfoPowerSupply := range chassisPowerInfoPowerSupplies {
-					go parseChassisPowerInfoPowerSupply(ch, SerialNumber, systemManufacturer, chassisID, chassisPowerInfoPowerSupply, wg5)
+	wg.Wait()
+	wg2.Wait()
+}
+
+// collectEnvironment collects the power and temperature of the chassis from
+// its EnvironmentMetrics.
+func (c *ChassisCollector) collectEnvironment(ch chan<- prometheus.Metric, chassis *redfishapi.Chassis, ChassisLabelValues []string, chassisLogContext *log.Entry) {
+	environmentMetrics, err := chassis.EnvironmentMetrics()
+	if err != nil {
+		chassisLogContext.WithField("operation", "chassis.EnvironmentMetrics()").WithError(err).Error("error getting environment metrics from chassis")
+		return
+	} else if environmentMetrics == nil {
+		return
+	}
+
+	if environmentMetrics.PowerWatts != nil {
+		ch <- prometheus.MustNewConstMetric(c.metrics["chassis_environment_power_watts"].desc, prometheus.GaugeValue, float64(environmentMetrics.PowerWatts.Reading), ChassisLabelValues...)
+	}
+	if environmentMetrics.TemperatureCelsius != nil {
+		ch <- prometheus.MustNewConstMetric(c.metrics["chassis_environment_temperature_celsius"].desc, prometheus.GaugeValue, float64(environmentMetrics.TemperatureCelsius.Reading), ChassisLabelValues...)
+	}
+}
+
+// collectPower collects the power supplies from the PowerSubsystem of the
+// chassis, falling back to the deprecated Power resource. The metrics of the
+// power supplies are only read for the readings tier.
+func (c *ChassisCollector) collectPower(ch chan<- prometheus.Metric, chassis *redfishapi.Chassis, SerialNumber, systemManufacturer string, chassisLogContext *log.Entry) {
+	chassisID := chassis.ID
+
+	var chassisPowerInfoPowerSupplies []redfishapi.PowerSupply
+	powerSubsystem, err := chassis.PowerSubsystem()
+	if err != nil {
+		chassisLogContext.WithField("operation", "chassis.PowerSubsystem()").WithError(err).Error("error getting power subsystem from chassis")
+	} else if powerSubsystem != nil {
+		powerSupplies, err := powerSubsystem.PowerSupplies()
+		if err != nil {
+			chassisLogContext.WithField("operation", "powerSubsystem.PowerSupplies()").WithError(err).Error("error getting power supplies from power subsystem")
+		}
+		for _, powerSupply := range powerSupplies {
+			var powerSupplyMetrics *redfishapi.PowerSupplyMetrics
+			if c.tier != InventoryTier {
+				powerSupplyMetrics, err = powerSupply.Metrics()
+				if err != nil {
+					chassisLogContext.WithFields(log.Fields{"operation": "powerSupply.Metrics()", "power_supply": powerSupply.ID}).WithError(err).Error("error getting power supply metrics")
 				}
 			}
-			chassisLogContext.Info("collector scrape completed")
+			chassisPowerInfoPowerSupplies = append(chassisPowerInfoPowerSupplies, powerSupplyFromPowerSupplyUnit(powerSupply, powerSupplyMetrics))
+		}
+	}
 
+	if len(chassisPowerInfoPowerSupplies) == 0 {
+		chassisPowerInfo, err := chassis.Power()
+		if err != nil {
+			chassisLogContext.WithField("operation", "chassis.Power()").WithError(err).Error("error getting power data from chassis")
+		} else if chassisPowerInfo == nil {
+			chassisLogContext.WithField("operation", "chassis.Power()").Info("no power data found")
+		} else {
+			chassisPowerInfoPowerSupplies = chassisPowerInfo.PowerSupplies
 		}
 	}
 
-	c.collectorScrapeStatus.WithLabelValues("chassis").Set(float64(1))
+	// powerSupply
+	wg5 := &sync.WaitGroup{}
+	wg5.Add(len(chassisPowerInfoPowerSupplies))
+	for _, chassisPowerInfoPowerSupply := range chassisPowerInfoPowerSupplies {
+		if c.tier != InventoryTier {
+			c.health.Observe("power_supply", chassisID, chassisPowerInfoPowerSupply.Name, chassisPowerInfoPowerSupply.Status.Health)
+		}
+		go parseChassisPowerInfoPowerSupply(ch, SerialNumber, systemManufacturer, chassisID, chassisPowerInfoPowerSupply, c.tier, wg5)
+	}
+	wg5.Wait()
+}
+
+// temperatureFromSensor maps a temperature Sensor onto the deprecated
+// Temperature so both are exported with the same labels.
+func temperatureFromSensor(sensor *redfishapi.Sensor) redfishapi.Temperature {
+	return redfishapi.Temperature{
+		Entity:          sensor.Entity,
+		MemberID:        sensor.ID,
+		PhysicalContext: string(sensor.PhysicalContext),
+		ReadingCelsius:  sensor.Reading,
+		Status:          sensor.Status,
+	}
+}
+
+// thermalMemberIDs holds the MemberIds of the members of the deprecated
+// Thermal resource by the URI of the Sensor or Fan they are read from, or by
+// their name if the service doesn't link them.
+type thermalMemberIDs map[string]string
+
+// add records the MemberId of a Thermal member. Names shared by several
+// members are ambiguous and not recorded.
+func (ids thermalMemberIDs) add(dataSourceURI, name, memberID string) {
+	if dataSourceURI != "" {
+		ids[dataSourceURI] = memberID
+	}
+	if name != "" {
+		if _, ok := ids["name:"+name]; ok {
+			memberID = ""
+		}
+		ids["name:"+name] = memberID
+	}
+}
+
+// get returns the MemberId of the Thermal member read from the resource at
+// uri or named name, or id if there is none.
+func (ids thermalMemberIDs) get(uri, name, id string) string {
+	if memberID, ok := ids[uri]; ok && uri != "" {
+		return memberID
+	}
+	if memberID := ids["name:"+name]; memberID != "" {
+		return memberID
+	}
+	return id
+}
+
+// fanFromCoolingFan maps a CoolingFan onto the deprecated Fan so both are
+// exported with the same labels.
+func fanFromCoolingFan(fan *redfishapi.CoolingFan) redfishapi.Fan {
+	reading, readingUnits := fan.SpeedPercent.Reading, redfishapi.PercentReadingUnits
+	if fan.SpeedPercent.SpeedRPM > 0 {
+		reading, readingUnits = fan.SpeedPercent.SpeedRPM, redfishapi.RPMReadingUnits
+	}
+
+	return redfishapi.Fan{
+		Entity:       fan.Entity,
+		MemberID:     fan.ID,
+		Reading:      reading,
+		ReadingUnits: readingUnits,
+		Status:       fan.Status,
+	}
+}
+
+// powerSupplyFromPowerSupplyUnit maps a PowerSupplyUnit and its metrics onto
+// the deprecated PowerSupply so both are exported with the same labels.
+func powerSupplyFromPowerSupplyUnit(powerSupply *redfishapi.PowerSupplyUnit, powerSupplyMetrics *redfishapi.PowerSupplyMetrics) redfishapi.PowerSupply {
+	chassisPowerInfoPowerSupply := redfishapi.PowerSupply{
+		Entity:             powerSupply.Entity,
+		MemberID:           powerSupply.ID,
+		PowerCapacityWatts: powerSupply.PowerCapacityWatts,
+		SerialNumber:       powerSupply.SerialNumber,
+		Status:             powerSupply.Status,
+	}
+	if powerSupplyMetrics != nil {
+		chassisPowerInfoPowerSupply.LastPowerOutputWatts = powerSupplyMetrics.OutputPowerWatts.Reading
+	}
+
+	return chassisPowerInfoPowerSupply
 }
 
 func parseChassisTemperature(ch chan<- prometheus.Metric, SerialNumber, systemManufacturer, chassisID string, chassisTemperature redfishapi.Temperature, wg *sync.WaitGroup) {
@@ -257,14 +595,17 @@ func parseChassisTemperature(ch chan<- prometheus.Metric, SerialNumber, systemMa
 	ch <- prometheus.MustNewConstMetric(chassisMetrics["chassis_temperature_celsius"].desc, prometheus.GaugeValue, float64(chassisTemperatureReadingCelsius), chassisTemperatureLabelvalues...)
 }
 
-func parseChassisFan(ch chan<- prometheus.Metric, SerialNumber, systemManufacturer, chassisID string, chassisFan redfishapi.Fan, wg *sync.WaitGroup) {
+// parseChassisFan exports a fan. The fans of the deprecated Thermal resource
+// keep their fan_rpm_percentage metric, which holds the reading whatever its
+// units, next to the readings by units.
+func parseChassisFan(ch chan<- prometheus.Metric, SerialNumber, systemManufacturer, chassisID string, chassisFan redfishapi.Fan, legacy bool, wg *sync.WaitGroup) {
 	defer wg.Done()
 	chassisFanID := chassisFan.MemberID
 	chassisFanName := chassisFan.Name
 	chassisFanStaus := chassisFan.Status
 	chassisFanStausHealth := chassisFanStaus.Health
 	chassisFanStausState := chassisFanStaus.State
-	chassisFanRPM := chassisFan.Reading
+	chassisFanReading := chassisFan.Reading
 
 	chassisFanLabelvalues := []string{SerialNumber, systemManufacturer, "fan", chassisID, chassisFanName, chassisFanID}
 
@@ -275,11 +616,19 @@ func parseChassisFan(ch chan<- prometheus.Metric, SerialNumber, systemManufactur
 	if chassisFanStausStateValue, ok := parseCommonStatusState(chassisFanStausState); ok {
 		ch <- prometheus.MustNewConstMetric(chassisMetrics["chassis_fan_state"].desc, prometheus.GaugeValue, chassisFanStausStateValue, chassisFanLabelvalues...)
 	}
-	ch <- prometheus.MustNewConstMetric(chassisMetrics["chassis_fan_rpm"].desc, prometheus.GaugeValue, float64(chassisFanRPM), chassisFanLabelvalues...)
+	if legacy {
+		ch <- prometheus.MustNewConstMetric(chassisMetrics["chassis_fan_rpm"].desc, prometheus.GaugeValue, float64(chassisFanReading), chassisFanLabelvalues...)
+	}
+	// services predating ReadingUnits report rpm
+	if chassisFan.ReadingUnits == redfishapi.PercentReadingUnits {
+		ch <- prometheus.MustNewConstMetric(chassisMetrics["chassis_fan_speed_percent"].desc, prometheus.GaugeValue, float64(chassisFanReading), chassisFanLabelvalues...)
+	} else {
+		ch <- prometheus.MustNewConstMetric(chassisMetrics["chassis_fan_speed_rpm"].desc, prometheus.GaugeValue, float64(chassisFanReading), chassisFanLabelvalues...)
+	}
 
 }
 
-func parseChassisPowerInfoPowerSupply(ch chan<- prometheus.Metric, SerialNumber, systemManufacturer, chassisID string, chassisPowerInfoPowerSupply redfishapi.PowerSupply, wg *sync.WaitGroup) {
+func parseChassisPowerInfoPowerSupply(ch chan<- prometheus.Metric, SerialNumber, systemManufacturer, chassisID string, chassisPowerInfoPowerSupply redfishapi.PowerSupply, tier string, wg *sync.WaitGroup) {
 
 	defer wg.Done()
 	chassisPowerInfoPowerSupplyName := chassisPowerInfoPowerSupply.Name
@@ -294,6 +643,10 @@ func parseChassisPowerInfoPowerSupply(ch chan<- prometheus.Metric, SerialNumber,
 	chassisPowerInfoPowerSupplyState := chassisPowerInfoPowerSupply.Status.State
 	chassisPowerInfoPowerSupplyHealthStatus := chassisPowerInfoPowerSupply.Status.Health
 	chassisPowerSupplyLabelvalues := []string{SerialNumber, systemManufacturer, "power_supply", chassisID, chassisPowerInfoPowerSupplyName, chassisPowerInfoPowerSupplyID}
+	if tier == InventoryTier {
+		ch <- prometheus.MustNewConstMetric(chassisMetrics["chassis_power_powersupply_power_capacity_watts"].desc, prometheus.GaugeValue, float64(chassisPowerInfoPowerSupplyPowerCapacityWatts), chassisPowerSupplyLabelvalues...)
+		return
+	}
 	if chassisPowerInfoPowerSupplyStateValue, ok := parseCommonStatusState(chassisPowerInfoPowerSupplyState); ok {
 		ch <- prometheus.MustNewConstMetric(chassisMetrics["chassis_power_powersupply_state"].desc, prometheus.GaugeValue, chassisPowerInfoPowerSupplyStateValue, chassisPowerSupplyLabelvalues...)
 	}
@@ -301,5 +654,4 @@ func parseChassisPowerInfoPowerSupply(ch chan<- prometheus.Metric, SerialNumber,
 		ch <- prometheus.MustNewConstMetric(chassisMetrics["chassis_power_powersupply_health_status"].desc, prometheus.GaugeValue, chassisPowerInfoPowerSupplyHealthStatusValue, chassisPowerSupplyLabelvalues...)
 	}
 	ch <- prometheus.MustNewConstMetric(chassisMetrics["chassis_power_powersupply_last_power_output_watts"].desc, prometheus.GaugeValue, float64(chassisPowerInfoPowerSupplyLastPowerOutputWatts), chassisPowerSupplyLabelvalues...)
-	ch <- prometheus.MustNewConstMetric(chassisMetrics["chassis_power_powersupply_power_capacity_watts"].desc, prometheus.GaugeValue, float64(chassisPowerInfoPowerSupplyPowerCapacityWatts), chassisPowerSupplyLabelvalues...)
 }
\ No newline at end of file
@@ -0,0 +1,56 @@
+package collector
+
+import (
+	"reflect"
+	"testing"
+
+	redfishcommon "github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// TestChassisLocation tests the location labels of a chassis.
+func TestChassisLocation(t *testing.T) {
+	zero, twelve := 0, 12
+	tests := []struct {
+		name     string
+		location redfishcommon.Location
+		labels   []string
+	}{
+		{"offset with units", redfishcommon.Location{Placement: redfishcommon.Placement{Rack: "R1", Row: "A", RackOffset: &twelve, RackOffsetUnits: "EIA_310"}}, []string{"R1", "A", "12", "EIA_310", ""}},
+		{"offset without units", redfishcommon.Location{Placement: redfishcommon.Placement{RackOffset: &twelve}}, []string{"", "", "12", "", ""}},
+		{"bottom of the rack", redfishcommon.Location{Placement: redfishcommon.Placement{RackOffset: &zero}}, []string{"", "", "0", "", ""}},
+		{"no placement", redfishcommon.Location{}, nil},
+	}
+
+	for _, test := range tests {
+		labels, ok := chassisLocation(test.location)
+		if ok != (test.labels != nil) || !reflect.DeepEqual(labels, test.labels) {
+			t.Errorf("%s: got %q and %t, want %q", test.name, labels, ok, test.labels)
+		}
+	}
+}
+
+// TestThermalMemberIDs tests the sensors and fans keep the MemberIds of the
+// Thermal resource.
+func TestThermalMemberIDs(t *testing.T) {
+	ids := thermalMemberIDs{}
+	ids.add("/redfish/v1/Chassis/1/Sensors/CPU1Temp", "CPU1 Temp", "0")
+	ids.add("", "Inlet Temp", "1")
+	ids.add("", "Fan", "2")
+	ids.add("", "Fan", "3")
+
+	tests := []struct {
+		uri, name, id string
+		expected      string
+	}{
+		{"/redfish/v1/Chassis/1/Sensors/CPU1Temp", "CPU 1", "CPU1Temp", "0"},
+		{"/redfish/v1/Chassis/1/Sensors/InletTemp", "Inlet Temp", "InletTemp", "1"},
+		{"/redfish/v1/Chassis/1/Sensors/Fan1", "Fan", "Fan1", "Fan1"},
+		{"/redfish/v1/Chassis/1/Sensors/ExhaustTemp", "Exhaust Temp", "ExhaustTemp", "ExhaustTemp"},
+		{"", "", "PSU1Temp", "PSU1Temp"},
+	}
+	for _, test := range tests {
+		if id := ids.get(test.uri, test.name, test.id); id != test.expected {
+			t.Errorf("get(%q, %q, %q): expected %q, got %q", test.uri, test.name, test.id, test.expected, id)
+		}
+	}
+}
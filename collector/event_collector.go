@@ -0,0 +1,117 @@
+package collector
+
+import (
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/redfishapi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// EventSubsystem is the event subsystem
+var (
+	EventSubsystem        = "events"
+	EventLabelNames       = []string{"target", "severity", "message_id"}
+	EventTargetLabelNames = []string{"target"}
+)
+
+// messageIDRegexp matches the message ids of the redfish registries, such as
+// Base.1.8.ResourceCreated, capturing the registry and the message key.
+var messageIDRegexp = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_]{0,31})(?:\.[0-9]{1,4}){0,3}\.([A-Za-z0-9_]{1,64})$`)
+
+// EventCollector counts the events received from the redfish event service of
+// the targets. It implements prometheus.Collector.
+type EventCollector struct {
+	eventsTotal        *prometheus.CounterVec
+	lastEventTimestamp *prometheus.GaugeVec
+
+	// lastEventTimes holds the latest event time of every target, events
+	// delivered late or with a BMC clock set back don't move it backwards.
+	mutex          sync.Mutex
+	lastEventTimes map[string]time.Time
+}
+
+// NewEventCollector returns a collector that counting redfish events
+func NewEventCollector() *EventCollector {
+	return &EventCollector{
+		eventsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "events_total",
+				Help:      "total number of redfish events received",
+			},
+			EventLabelNames,
+		),
+		lastEventTimestamp: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Subsystem: EventSubsystem,
+				Name:      "last_timestamp_seconds",
+				Help:      "timestamp of the last redfish event received",
+			},
+			EventTargetLabelNames,
+		),
+		lastEventTimes: make(map[string]time.Time),
+	}
+}
+
+// Describe implemented prometheus.Collector
+func (e *EventCollector) Describe(ch chan<- *prometheus.Desc) {
+	e.eventsTotal.Describe(ch)
+	e.lastEventTimestamp.Describe(ch)
+}
+
+// Collect implemented prometheus.Collector
+func (e *EventCollector) Collect(ch chan<- prometheus.Metric) {
+	e.eventsTotal.Collect(ch)
+	e.lastEventTimestamp.Collect(ch)
+}
+
+// Observe records the events of an event payload received from target.
+func (e *EventCollector) Observe(target string, event *redfishapi.Event) {
+	for _, record := range event.Events {
+		e.eventsTotal.WithLabelValues(target, eventSeverity(record.EventSeverity()), eventMessageID(record.MessageID)).Inc()
+
+		eventTime := time.Now()
+		if t, err := time.Parse(time.RFC3339, record.EventTimestamp); err == nil {
+			eventTime = t
+		}
+		e.observeEventTime(target, eventTime)
+	}
+}
+
+// observeEventTime sets the last event timestamp of target to eventTime if
+// it is later than the events seen before.
+func (e *EventCollector) observeEventTime(target string, eventTime time.Time) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if !eventTime.After(e.lastEventTimes[target]) {
+		return
+	}
+	e.lastEventTimes[target] = eventTime
+	e.lastEventTimestamp.WithLabelValues(target).Set(float64(eventTime.Unix()))
+}
+
+// eventMessageID returns a message id without the registry version, such as
+// Base.ResourceCreated for Base.1.8.ResourceCreated, so the ids of the
+// registry versions of the firmware releases share a series. It returns
+// unknown for malformed ids.
+func eventMessageID(messageID string) string {
+	match := messageIDRegexp.FindStringSubmatch(messageID)
+	if match == nil {
+		return "unknown"
+	}
+	return match[1] + "." + match[2]
+}
+
+// eventSeverity returns the severity of an event, or unknown if it is not
+// one of the redfish severities.
+func eventSeverity(severity string) string {
+	switch severity {
+	case "OK", "Warning", "Critical":
+		return severity
+	}
+	return "unknown"
+}
@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/redfishapi"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestEventMessageID tests bounding message ids by dropping the registry
+// version and malformed ids.
+func TestEventMessageID(t *testing.T) {
+	tests := map[string]string{
+		"Base.1.8.ResourceCreated":       "Base.ResourceCreated",
+		"Base.1.13.0.ResourceCreated":    "Base.ResourceCreated",
+		"iLOEvents.2.1.ServerPoweredOff": "iLOEvents.ServerPoweredOff",
+		"IDRAC.2.4.SYS1003":              "IDRAC.SYS1003",
+		"EventLog.Alert":                 "EventLog.Alert",
+		"EventLog":                       "unknown",
+		"":                               "unknown",
+		"1.0.Alert":                      "unknown",
+		"Base Registry.1.0.Alert":        "unknown",
+		"Base.1.0.Resource Created":      "unknown",
+		"Base.1.0.Alert.Extra":           "unknown",
+		"AVeryLongRegistryPrefixThatGoesOnAndOn.1.0.Alert": "unknown",
+	}
+
+	for messageID, expected := range tests {
+		if id := eventMessageID(messageID); id != expected {
+			t.Errorf("eventMessageID(%q): expected %q, got %q", messageID, expected, id)
+		}
+	}
+}
+
+// TestEventCollectorLastTimestamp tests the last event timestamp only moves
+// forward.
+func TestEventCollectorLastTimestamp(t *testing.T) {
+	collector := NewEventCollector()
+	for _, timestamp := range []string{"2021-06-01T10:00:00Z", "2021-06-01T12:00:00Z", "2021-06-01T11:00:00Z"} {
+		collector.Observe("10.0.0.1", &redfishapi.Event{Events: []redfishapi.EventRecord{{MessageID: "Base.1.8.ResourceCreated", EventTimestamp: timestamp}}})
+	}
+
+	expected := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	if got := testutil.ToFloat64(collector.lastEventTimestamp.WithLabelValues("10.0.0.1")); got != float64(expected.Unix()) {
+		t.Errorf("got last event timestamp %v, want %v", got, float64(expected.Unix()))
+	}
+	if got := testutil.ToFloat64(collector.eventsTotal.WithLabelValues("10.0.0.1", "unknown", "Base.ResourceCreated")); got != 3 {
+		t.Errorf("got %v events, want 3", got)
+	}
+}
@@ -0,0 +1,151 @@
+package collector
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FirmwareBaseline holds the expected firmware versions of one hardware model.
+type FirmwareBaseline struct {
+	Components []FirmwareComponentBaseline `yaml:"components"`
+}
+
+// FirmwareComponentBaseline gives the expected version of the firmware
+// components whose name matches Name.
+type FirmwareComponentBaseline struct {
+	// Name is a regular expression matched against the firmware inventory name.
+	Name string `yaml:"name"`
+	// MinVersion is the lowest version considered compliant.
+	MinVersion string `yaml:"min_version"`
+	// ExactVersion is the only version considered compliant.
+	ExactVersion string `yaml:"exact_version"`
+	// VersionPattern is an optional regular expression extracting the version
+	// from the version string reported by the BMC, using the first capture
+	// group if there is one.
+	VersionPattern string `yaml:"version_pattern"`
+
+	nameRegexp    *regexp.Regexp
+	versionRegexp *regexp.Regexp
+}
+
+// versionRegexp finds the first dotted version in a firmware version string,
+// such as 2.52 in the "U30 v2.52 (05/21/2021)" reported for HPE system ROMs.
+var versionRegexp = regexp.MustCompile(`\d+(?:\.\w+)+`)
+
+// UnmarshalYAML implements yaml.Unmarshaler and validates the baseline.
+func (b *FirmwareComponentBaseline) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain FirmwareComponentBaseline
+	if err := unmarshal((*plain)(b)); err != nil {
+		return err
+	}
+
+	if b.Name == "" {
+		return fmt.Errorf("firmware baseline component must have a name")
+	}
+	if (b.MinVersion == "") == (b.ExactVersion == "") {
+		return fmt.Errorf("firmware baseline component %q must set exactly one of min_version and exact_version", b.Name)
+	}
+
+	nameRegexp, err := regexp.Compile("^(?:" + b.Name + ")$")
+	if err != nil {
+		return fmt.Errorf("invalid firmware baseline component name %q: %s", b.Name, err)
+	}
+	b.nameRegexp = nameRegexp
+
+	if b.VersionPattern != "" {
+		versionRegexp, err := regexp.Compile(b.VersionPattern)
+		if err != nil {
+			return fmt.Errorf("invalid firmware baseline version pattern %q: %s", b.VersionPattern, err)
+		}
+		b.versionRegexp = versionRegexp
+	}
+
+	return nil
+}
+
+// Match returns the first component baseline whose name matches the given
+// firmware component name.
+func (b FirmwareBaseline) Match(name string) (*FirmwareComponentBaseline, bool) {
+	for i := range b.Components {
+		if b.Components[i].nameRegexp != nil && b.Components[i].nameRegexp.MatchString(name) {
+			return &b.Components[i], true
+		}
+	}
+	return nil, false
+}
+
+// Compliant reports whether version satisfies the component baseline.
+func (b *FirmwareComponentBaseline) Compliant(version string) bool {
+	version, ok := b.extractVersion(version)
+	if !ok {
+		return false
+	}
+	if b.ExactVersion != "" {
+		return compareVersions(version, extractVersion(b.ExactVersion)) == 0
+	}
+	return compareVersions(version, extractVersion(b.MinVersion)) >= 0
+}
+
+// extractVersion returns the comparable part of a reported version using the
+// version pattern of the component, it fails if the pattern does not match.
+func (b *FirmwareComponentBaseline) extractVersion(version string) (string, bool) {
+	if b.versionRegexp == nil {
+		return extractVersion(version), true
+	}
+	match := b.versionRegexp.FindStringSubmatch(version)
+	if match == nil {
+		return "", false
+	}
+	if len(match) > 1 {
+		return match[1], true
+	}
+	return match[0], true
+}
+
+// extractVersion returns the first dotted version found in version, or the
+// whole string if there is none.
+func extractVersion(version string) string {
+	if match := versionRegexp.FindString(version); match != "" {
+		return match
+	}
+	return strings.TrimSpace(version)
+}
+
+// compareVersions compares two firmware version strings segment by segment,
+// numerically where both segments are numbers. It returns -1, 0 or 1.
+func compareVersions(a, b string) int {
+	split := func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}
+	aParts := strings.FieldsFunc(strings.TrimSpace(a), split)
+	bParts := strings.FieldsFunc(strings.TrimSpace(b), split)
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		aPart, bPart := "0", "0"
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+
+		aNum, aErr := strconv.ParseUint(aPart, 10, 64)
+		bNum, bErr := strconv.ParseUint(bPart, 10, 64)
+		if aErr == nil && bErr == nil {
+			if aNum < bNum {
+				return -1
+			} else if aNum > bNum {
+				return 1
+			}
+			continue
+		}
+
+		if c := strings.Compare(strings.ToLower(aPart), strings.ToLower(bPart)); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
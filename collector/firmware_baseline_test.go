@@ -0,0 +1,139 @@
+package collector
+
+import (
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+var firmwareBaselineBody = `
+components:
+  - name: "BIOS"
+    min_version: "2.12.2"
+  - name: "Integrated Dell Remote Access Controller|iDRAC.*"
+    exact_version: "5.10.00.00"
+`
+
+// TestCompareVersions tests the ordering of firmware version strings.
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		result int
+	}{
+		{"2.12.2", "2.12.2", 0},
+		{"2.12.10", "2.12.2", 1},
+		{"2.9", "2.12.2", -1},
+		{"2.12", "2.12.0", 0},
+		{"5.10.00.00", "5.10.0.0", 0},
+		{"U30 v2.52 (05/21/2021)", "U30 v2.52 (05/21/2021)", 0},
+		{"1.0.3b", "1.0.3a", 1},
+	}
+
+	for _, test := range tests {
+		if result := compareVersions(test.a, test.b); result != test.result {
+			t.Errorf("compareVersions(%q, %q): expected %d, got %d", test.a, test.b, test.result, result)
+		}
+	}
+}
+
+// TestComponentCompliant tests version strings reported by iLO, iDRAC and
+// Inspur BMCs against component baselines.
+func TestComponentCompliant(t *testing.T) {
+	tests := []struct {
+		component FirmwareComponentBaseline
+		version   string
+		compliant bool
+	}{
+		// HPE iLO 5
+		{FirmwareComponentBaseline{MinVersion: "2.52"}, "U30 v2.52 (05/21/2021)", true},
+		{FirmwareComponentBaseline{MinVersion: "2.52"}, "U30 v2.60 (12/06/2021)", true},
+		{FirmwareComponentBaseline{MinVersion: "2.52"}, "U30 v2.44 (03/15/2021)", false},
+		{FirmwareComponentBaseline{MinVersion: "2.52"}, "U30 v2.10 (05/21/2021)", false},
+		{FirmwareComponentBaseline{MinVersion: "2.72"}, "2.72 Sep 04 2022", true},
+		{FirmwareComponentBaseline{MinVersion: "2.72"}, "2.65 Feb 11 2022", false},
+		{FirmwareComponentBaseline{ExactVersion: "U30 v2.52 (05/21/2021)"}, "U30 v2.52 (05/21/2021)", true},
+		// Dell iDRAC 9
+		{FirmwareComponentBaseline{MinVersion: "2.12.2"}, "2.12.2", true},
+		{FirmwareComponentBaseline{MinVersion: "2.12.2"}, "2.9.4", false},
+		{FirmwareComponentBaseline{ExactVersion: "5.10.00.00"}, "5.10.00.00", true},
+		{FirmwareComponentBaseline{ExactVersion: "5.10.00.00"}, "5.10.10.00", false},
+		{FirmwareComponentBaseline{MinVersion: "22.00.6"}, "22.00.6", true},
+		{FirmwareComponentBaseline{MinVersion: "22.00.6"}, "21.82.5", false},
+		// Inspur
+		{FirmwareComponentBaseline{MinVersion: "4.23.06"}, "4.23.06", true},
+		{FirmwareComponentBaseline{MinVersion: "4.23.06"}, "4.19.05", false},
+		{FirmwareComponentBaseline{MinVersion: "4.1.16"}, "4.1.21", true},
+		{FirmwareComponentBaseline{MinVersion: "4.1.16"}, "4.1.8", false},
+		{FirmwareComponentBaseline{MinVersion: "1.3"}, "V1.3", true},
+		{FirmwareComponentBaseline{MinVersion: "1.3"}, "V1.2", false},
+	}
+
+	for _, test := range tests {
+		if compliant := test.component.Compliant(test.version); compliant != test.compliant {
+			t.Errorf("Compliant(%q) against min %q exact %q: expected %t, got %t", test.version, test.component.MinVersion, test.component.ExactVersion, test.compliant, compliant)
+		}
+	}
+}
+
+// TestComponentVersionPattern tests extracting the version with a
+// per-component pattern.
+func TestComponentVersionPattern(t *testing.T) {
+	var component FirmwareComponentBaseline
+	err := yaml.Unmarshal([]byte(`{name: "System ROM", min_version: "2.52", version_pattern: "v(\\d+\\.\\d+)"}`), &component)
+	if err != nil {
+		t.Fatalf("Error decoding YAML: %s", err)
+	}
+
+	if !component.Compliant("U30 v2.52 (05/21/2021)") {
+		t.Errorf("Expected U30 v2.52 to be compliant")
+	}
+	if component.Compliant("U30 v2.44 (03/15/2021)") {
+		t.Errorf("Expected U30 v2.44 not to be compliant")
+	}
+	if component.Compliant("2.52") {
+		t.Errorf("Expected a version the pattern does not match not to be compliant")
+	}
+
+	err = yaml.Unmarshal([]byte(`{name: "System ROM", min_version: "2.52", version_pattern: "v("}`), &component)
+	if err == nil {
+		t.Errorf("Expected an error for an invalid version pattern")
+	}
+}
+
+// TestFirmwareBaseline tests the parsing and matching of firmware baselines.
+func TestFirmwareBaseline(t *testing.T) {
+	var result FirmwareBaseline
+	err := yaml.Unmarshal([]byte(firmwareBaselineBody), &result)
+
+	if err != nil {
+		t.Fatalf("Error decoding YAML: %s", err)
+	}
+
+	component, ok := result.Match("BIOS")
+	if !ok {
+		t.Fatalf("Expected BIOS to match a component baseline")
+	}
+	if !component.Compliant("2.13.3") {
+		t.Errorf("Expected BIOS 2.13.3 to be compliant")
+	}
+	if component.Compliant("2.11.2") {
+		t.Errorf("Expected BIOS 2.11.2 not to be compliant")
+	}
+
+	component, ok = result.Match("iDRAC Service Module")
+	if !ok {
+		t.Fatalf("Expected iDRAC Service Module to match a component baseline")
+	}
+	if component.Compliant("5.10.10.00") {
+		t.Errorf("Expected iDRAC 5.10.10.00 not to be compliant")
+	}
+
+	if _, ok := result.Match("BIOS Setup"); ok {
+		t.Errorf("Expected BIOS Setup not to match any component baseline")
+	}
+
+	err = yaml.Unmarshal([]byte(`components: [{name: "BIOS"}]`), &result)
+	if err == nil {
+		t.Errorf("Expected an error for a component baseline without a version")
+	}
+}
@@ -0,0 +1,208 @@
+package collector
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/magicst0ne/rackserver_exporter/redfish"
+	"github.com/magicst0ne/rackserver_exporter/redfish/redfishapi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// FirmwareSubsystem is the firmware subsystem
+var (
+	FirmwareSubsystem            = "firmware"
+	FirmwareLabelNames           = []string{"sn", "mfr", "resource", "firmware_id", "component"}
+	FirmwareInfoLabelNames       = []string{"sn", "mfr", "resource", "firmware_id", "component", "version", "updateable"}
+	FirmwareComplianceLabelNames = []string{"sn", "mfr", "resource", "firmware_id", "component", "hw_model", "version", "expected_version"}
+
+	firmwareMetrics = map[string]firmwareMetric{
+		"firmware_info": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, FirmwareSubsystem, "info"),
+				"firmware component version, value is always 1",
+				FirmwareInfoLabelNames,
+				nil,
+			),
+		},
+		"firmware_health": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, FirmwareSubsystem, "health"),
+				"health of firmware component,1(OK),2(Warning),3(Critical)",
+				FirmwareLabelNames,
+				nil,
+			),
+		},
+		"firmware_state": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, FirmwareSubsystem, "state"),
+				"state of firmware component,1(Enabled),2(Disabled),3(StandbyOffinline),4(StandbySpare),5(InTest),6(Starting),7(Absent),8(UnavailableOffline),9(Deferring),10(Quiesced),11(Updating)",
+				FirmwareLabelNames,
+				nil,
+			),
+		},
+		"firmware_compliant": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, FirmwareSubsystem, "compliant"),
+				"firmware component matches the configured baseline of the hardware model,1(compliant),0(not compliant)",
+				FirmwareComplianceLabelNames,
+				nil,
+			),
+		},
+	}
+)
+
+// FirmwareCollector implements the prometheus.Collector.
+type FirmwareCollector struct {
+	redfishClient         *redfish.APIClient
+	metrics               map[string]firmwareMetric
+	baselines             map[string]FirmwareBaseline
+	collectorScrapeStatus *prometheus.GaugeVec
+	Log                   *log.Entry
+}
+
+type firmwareMetric struct {
+	desc *prometheus.Desc
+}
+
+// NewFirmwareCollector returns a collector that collecting firmware inventory
+func NewFirmwareCollector(namespace string, redfishClient *redfish.APIClient, baselines map[string]FirmwareBaseline, logger *log.Entry) *FirmwareCollector {
+	return &FirmwareCollector{
+		redfishClient: redfishClient,
+		metrics:       firmwareMetrics,
+		baselines:     baselines,
+		Log: logger.WithFields(log.Fields{
+			"collector": "FirmwareCollector",
+		}),
+		collectorScrapeStatus: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "collector_scrape_status",
+				Help:      "collector_scrape_status",
+			},
+			[]string{"collector"},
+		),
+	}
+}
+
+// Describe implemented prometheus.Collector
+func (f *FirmwareCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range f.metrics {
+		ch <- metric.desc
+	}
+	f.collectorScrapeStatus.Describe(ch)
+}
+
+// Collect implemented prometheus.Collector
+func (f *FirmwareCollector) Collect(ch chan<- prometheus.Metric) {
+	collectorLogContext := f.Log
+	service := f.redfishClient.Service
+
+	updateService, err := service.UpdateService()
+	if err != nil {
+		collectorLogContext.WithField("operation", "service.UpdateService()").WithError(err).Error("error getting update service from service")
+		return
+	} else if updateService == nil {
+		collectorLogContext.WithField("operation", "service.UpdateService()").Info("no update service found")
+		return
+	}
+
+	collectorLogContext.Info("collector scrape started")
+	firmwares, err := updateService.FirmwareInventories()
+	if err != nil {
+		collectorLogContext.WithField("operation", "updateService.FirmwareInventories()").WithError(err).Error("error getting firmware inventory from update service")
+	}
+
+	// the firmware inventory belongs to the BMC, it carries the identity of
+	// the first system the BMC manages, the only one of a rack server
+	systems, err := service.Systems()
+	if err != nil {
+		collectorLogContext.WithField("operation", "service.Systems()").WithError(err).Error("error getting systems from service")
+	} else if len(systems) == 0 {
+		collectorLogContext.WithField("operation", "service.Systems()").Info("no system found")
+	}
+	SerialNumber, systemManufacturer := "", "Unknown"
+	if len(systems) > 0 {
+		SerialNumber, systemManufacturer = systemIdentity(systems[0])
+	}
+
+	for _, firmware := range firmwares {
+		parseFirmware(ch, SerialNumber, systemManufacturer, firmware)
+	}
+
+	if len(f.baselines) > 0 {
+		f.collectCompliance(ch, SerialNumber, systemManufacturer, firmwares, systems)
+	}
+	collectorLogContext.Info("collector scrape completed")
+
+	f.collectorScrapeStatus.WithLabelValues("firmware").Set(float64(1))
+}
+
+// collectCompliance compares the firmware inventory against the baselines
+// configured for the models of the systems.
+func (f *FirmwareCollector) collectCompliance(ch chan<- prometheus.Metric, SerialNumber, systemManufacturer string, firmwares []*redfishapi.SoftwareInventory, systems []*redfishapi.ComputerSystem) {
+	collectorLogContext := f.Log
+
+	// the firmware inventory belongs to the BMC, compare it once against the
+	// baseline of every distinct model it manages
+	systemModels := map[string]bool{}
+	for _, system := range systems {
+		if systemModels[system.Model] {
+			continue
+		}
+		systemModels[system.Model] = true
+
+		baseline, ok := f.baselines[system.Model]
+		if !ok {
+			collectorLogContext.WithField("hw_model", system.Model).Info("no firmware baseline found")
+			continue
+		}
+		collectBaselineCompliance(ch, f.metrics["firmware_compliant"].desc, SerialNumber, systemManufacturer, firmwares, system.Model, baseline)
+	}
+}
+
+// collectBaselineCompliance emits the compliance of the firmware inventory
+// against the baseline of one hardware model.
+func collectBaselineCompliance(ch chan<- prometheus.Metric, desc *prometheus.Desc, SerialNumber, systemManufacturer string, firmwares []*redfishapi.SoftwareInventory, systemModel string, baseline FirmwareBaseline) {
+	for _, firmware := range firmwares {
+		// Dell keeps the previously installed images in the inventory too
+		if strings.HasPrefix(firmware.ID, "Previous") {
+			continue
+		}
+
+		firmwareName := firmware.Name
+		if firmwareName == "" {
+			firmwareName = firmware.ID
+		}
+		component, ok := baseline.Match(firmwareName)
+		if !ok {
+			continue
+		}
+
+		expectedVersion := component.ExactVersion
+		if expectedVersion == "" {
+			expectedVersion = ">=" + component.MinVersion
+		}
+		firmwareComplianceLabelValues := []string{SerialNumber, systemManufacturer, "firmware", firmware.ID, firmwareName, systemModel, firmware.Version, expectedVersion}
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, boolToFloat64(component.Compliant(firmware.Version)), firmwareComplianceLabelValues...)
+	}
+}
+
+func parseFirmware(ch chan<- prometheus.Metric, SerialNumber, systemManufacturer string, firmware *redfishapi.SoftwareInventory) {
+	firmwareID := firmware.ID
+	firmwareName := firmware.Name
+	if firmwareName == "" {
+		firmwareName = firmwareID
+	}
+	firmwareLabelValues := []string{SerialNumber, systemManufacturer, "firmware", firmwareID, firmwareName}
+	firmwareInfoLabelValues := []string{SerialNumber, systemManufacturer, "firmware", firmwareID, firmwareName, firmware.Version, strconv.FormatBool(firmware.Updateable)}
+
+	ch <- prometheus.MustNewConstMetric(firmwareMetrics["firmware_info"].desc, prometheus.GaugeValue, float64(1), firmwareInfoLabelValues...)
+	if firmwareHealthValue, ok := parseCommonStatusHealth(firmware.Status.Health); ok {
+		ch <- prometheus.MustNewConstMetric(firmwareMetrics["firmware_health"].desc, prometheus.GaugeValue, firmwareHealthValue, firmwareLabelValues...)
+	}
+	if firmwareStateValue, ok := parseCommonStatusState(firmware.Status.State); ok {
+		ch <- prometheus.MustNewConstMetric(firmwareMetrics["firmware_state"].desc, prometheus.GaugeValue, firmwareStateValue, firmwareLabelValues...)
+	}
+}
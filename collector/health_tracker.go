@@ -0,0 +1,96 @@
+package collector
+
+import (
+	"sync"
+	"time"
+
+	redfishcommon "github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// HealthTransition is a change of the health of a component between two scrapes.
+type HealthTransition struct {
+	Target         string    `json:"target"`
+	Component      string    `json:"component"`
+	Resource       string    `json:"resource"`
+	ID             string    `json:"id"`
+	PreviousHealth string    `json:"previous_health"`
+	Health         string    `json:"health"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// HealthNotifier is notified of the health transitions.
+type HealthNotifier interface {
+	Notify(transition *HealthTransition)
+}
+
+// HealthTracker remembers the last health of the components of every target
+// and notifies the transitions.
+type HealthTracker struct {
+	mutex    sync.Mutex
+	health   map[string]map[string]redfishcommon.Health
+	notifier HealthNotifier
+}
+
+// NewHealthTracker returns a HealthTracker notifying notifier
+func NewHealthTracker(notifier HealthNotifier) *HealthTracker {
+	return &HealthTracker{
+		health:   make(map[string]map[string]redfishcommon.Health),
+		notifier: notifier,
+	}
+}
+
+// ForTarget returns the TargetHealth recording the health of the components of target.
+func (t *HealthTracker) ForTarget(target string) *TargetHealth {
+	if t == nil {
+		return nil
+	}
+	return &TargetHealth{tracker: t, target: target}
+}
+
+func (t *HealthTracker) observe(target, component, resource, id string, health redfishcommon.Health) {
+	// components not reporting health are not tracked
+	if health == "" {
+		return
+	}
+
+	key := component + "/" + resource + "/" + id
+
+	t.mutex.Lock()
+	targetHealth, ok := t.health[target]
+	if !ok {
+		targetHealth = make(map[string]redfishcommon.Health)
+		t.health[target] = targetHealth
+	}
+	previous, known := targetHealth[key]
+	targetHealth[key] = health
+	t.mutex.Unlock()
+
+	if !known || previous == health {
+		return
+	}
+
+	t.notifier.Notify(&HealthTransition{
+		Target:         target,
+		Component:      component,
+		Resource:       resource,
+		ID:             id,
+		PreviousHealth: string(previous),
+		Health:         string(health),
+		Timestamp:      time.Now(),
+	})
+}
+
+// TargetHealth records the health of the components of a target. A nil
+// TargetHealth records nothing.
+type TargetHealth struct {
+	tracker *HealthTracker
+	target  string
+}
+
+// Observe records the health of the component id of resource.
+func (h *TargetHealth) Observe(component, resource, id string, health redfishcommon.Health) {
+	if h == nil {
+		return
+	}
+	h.tracker.observe(h.target, component, resource, id, health)
+}
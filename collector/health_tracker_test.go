@@ -0,0 +1,37 @@
+package collector
+
+import (
+	"testing"
+)
+
+type recordingNotifier struct {
+	transitions []*HealthTransition
+}
+
+func (n *recordingNotifier) Notify(transition *HealthTransition) {
+	n.transitions = append(n.transitions, transition)
+}
+
+func TestHealthTracker(t *testing.T) {
+	notifier := &recordingNotifier{}
+	tracker := NewHealthTracker(notifier)
+
+	health := tracker.ForTarget("10.0.0.1")
+	health.Observe("fan", "1", "Fan1", "OK")
+	health.Observe("fan", "1", "Fan1", "OK")
+	health.Observe("fan", "1", "Fan1", "")
+	tracker.ForTarget("10.0.0.2").Observe("fan", "1", "Fan1", "Critical")
+	health.Observe("fan", "1", "Fan1", "Critical")
+
+	if len(notifier.transitions) != 1 {
+		t.Fatalf("got %d transitions, want 1", len(notifier.transitions))
+	}
+	transition := notifier.transitions[0]
+	if transition.Target != "10.0.0.1" || transition.PreviousHealth != "OK" || transition.Health != "Critical" {
+		t.Errorf("unexpected transition %+v", transition)
+	}
+
+	// a nil tracker records nothing
+	var nilTracker *HealthTracker
+	nilTracker.ForTarget("10.0.0.1").Observe("fan", "1", "Fan1", "OK")
+}
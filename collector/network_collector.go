@@ -0,0 +1,278 @@
+package collector
+
+import (
+	"github.com/apex/log"
+	"github.com/magicst0ne/rackserver_exporter/redfish"
+	"github.com/magicst0ne/rackserver_exporter/redfish/redfishapi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NetworkSubsystem is the network subsystem
+var (
+	NetworkSubsystem                       = "network"
+	NetworkAdapterLabelNames               = []string{"sn", "mfr", "resource", "chassis_id", "adapter_id", "adapter_model"}
+	NetworkAdapterInfoLabelNames           = []string{"sn", "mfr", "resource", "chassis_id", "adapter_id", "adapter_model", "adapter_mfr", "adapter_sn", "firmware_version"}
+	NetworkPortLabelNames                  = []string{"sn", "mfr", "resource", "chassis_id", "adapter_id", "port_id"}
+	NetworkPortInfoLabelNames              = []string{"sn", "mfr", "resource", "chassis_id", "adapter_id", "port_id", "mac_address", "link_technology"}
+	NetworkEthernetInterfaceLabelNames     = []string{"sn", "mfr", "resource", "system_id", "interface_id", "interface_name"}
+	NetworkEthernetInterfaceInfoLabelNames = []string{"sn", "mfr", "resource", "system_id", "interface_id", "interface_name", "mac_address"}
+
+	networkMetrics = map[string]networkMetric{
+		"network_adapter_health": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, NetworkSubsystem, "adapter_health"),
+				"health of network adapter,1(OK),2(Warning),3(Critical)",
+				NetworkAdapterLabelNames,
+				nil,
+			),
+		},
+		"network_adapter_state": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, NetworkSubsystem, "adapter_state"),
+				"state of network adapter,1(Enabled),2(Disabled),3(StandbyOffinline),4(StandbySpare),5(InTest),6(Starting),7(Absent),8(UnavailableOffline),9(Deferring),10(Quiesced),11(Updating)",
+				NetworkAdapterLabelNames,
+				nil,
+			),
+		},
+		"network_adapter_info": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, NetworkSubsystem, "adapter_info"),
+				"network adapter inventory and firmware version, value is always 1",
+				NetworkAdapterInfoLabelNames,
+				nil,
+			),
+		},
+		"network_port_link_status": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, NetworkSubsystem, "port_link_status"),
+				"link status of network port,1(Up),2(Down)",
+				NetworkPortLabelNames,
+				nil,
+			),
+		},
+		"network_port_speed_mbps": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, NetworkSubsystem, "port_speed_mbps"),
+				"current link speed of network port, Mbps",
+				NetworkPortLabelNames,
+				nil,
+			),
+		},
+		"network_port_health": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, NetworkSubsystem, "port_health"),
+				"health of network port,1(OK),2(Warning),3(Critical)",
+				NetworkPortLabelNames,
+				nil,
+			),
+		},
+		"network_port_info": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, NetworkSubsystem, "port_info"),
+				"network port addresses, value is always 1",
+				NetworkPortInfoLabelNames,
+				nil,
+			),
+		},
+		"network_ethernet_interface_link_status": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, NetworkSubsystem, "ethernet_interface_link_status"),
+				"link status of ethernet interface,1(LinkUp),2(LinkDown),3(NoLink)",
+				NetworkEthernetInterfaceLabelNames,
+				nil,
+			),
+		},
+		"network_ethernet_interface_speed_mbps": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, NetworkSubsystem, "ethernet_interface_speed_mbps"),
+				"link speed of ethernet interface, Mbps",
+				NetworkEthernetInterfaceLabelNames,
+				nil,
+			),
+		},
+		"network_ethernet_interface_health": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, NetworkSubsystem, "ethernet_interface_health"),
+				"health of ethernet interface,1(OK),2(Warning),3(Critical)",
+				NetworkEthernetInterfaceLabelNames,
+				nil,
+			),
+		},
+		"network_ethernet_interface_state": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, NetworkSubsystem, "ethernet_interface_state"),
+				"state of ethernet interface,1(Enabled),2(Disabled),3(StandbyOffinline),4(StandbySpare),5(InTest),6(Starting),7(Absent),8(UnavailableOffline),9(Deferring),10(Quiesced),11(Updating)",
+				NetworkEthernetInterfaceLabelNames,
+				nil,
+			),
+		},
+		"network_ethernet_interface_info": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, NetworkSubsystem, "ethernet_interface_info"),
+				"ethernet interface addresses, value is always 1",
+				NetworkEthernetInterfaceInfoLabelNames,
+				nil,
+			),
+		},
+	}
+)
+
+// NetworkCollector implements the prometheus.Collector.
+type NetworkCollector struct {
+	redfishClient         *redfish.APIClient
+	metrics               map[string]networkMetric
+	collectorScrapeStatus *prometheus.GaugeVec
+	Log                   *log.Entry
+}
+
+type networkMetric struct {
+	desc *prometheus.Desc
+}
+
+// NewNetworkCollector returns a collector that collecting network adapter and ethernet interface statistics
+func NewNetworkCollector(namespace string, redfishClient *redfish.APIClient, logger *log.Entry) *NetworkCollector {
+	return &NetworkCollector{
+		redfishClient: redfishClient,
+		metrics:       networkMetrics,
+		Log: logger.WithFields(log.Fields{
+			"collector": "NetworkCollector",
+		}),
+		collectorScrapeStatus: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "collector_scrape_status",
+				Help:      "collector_scrape_status",
+			},
+			[]string{"collector"},
+		),
+	}
+}
+
+// Describe implemented prometheus.Collector
+func (n *NetworkCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range n.metrics {
+		ch <- metric.desc
+	}
+	n.collectorScrapeStatus.Describe(ch)
+}
+
+// Collect implemented prometheus.Collector
+func (n *NetworkCollector) Collect(ch chan<- prometheus.Metric) {
+	collectorLogContext := n.Log
+	service := n.redfishClient.Service
+
+	systems, err := service.Systems()
+	if err != nil {
+		collectorLogContext.WithField("operation", "service.Systems()").WithError(err).Error("error getting systems from service")
+	}
+	SerialNumber, systemManufacturer := "", "Unknown"
+	if len(systems) > 0 {
+		SerialNumber, systemManufacturer = systemIdentity(systems[0])
+	}
+
+	// network adapters and their ports hang off the chassis
+	if chassises, err := service.Chassis(); err != nil {
+		collectorLogContext.WithField("operation", "service.Chassis()").WithError(err).Error("error getting chassis from service")
+	} else {
+		for _, chassis := range chassises {
+			chassisLogContext := collectorLogContext.WithField("Chassis", chassis.ID)
+
+			networkAdapters, err := chassis.NetworkAdapters()
+			if err != nil {
+				chassisLogContext.WithField("operation", "chassis.NetworkAdapters()").WithError(err).Error("error getting network adapters from chassis")
+			}
+			for _, networkAdapter := range networkAdapters {
+				n.collectNetworkAdapter(ch, SerialNumber, systemManufacturer, chassis.ID, networkAdapter, chassisLogContext)
+			}
+		}
+	}
+
+	// ethernet interfaces are reported as seen by the host
+	for _, system := range systems {
+		systemLogContext := collectorLogContext.WithField("System", system.ID)
+
+		ethernetInterfaces, err := system.EthernetInterfaces()
+		if err != nil {
+			systemLogContext.WithField("operation", "system.EthernetInterfaces()").WithError(err).Error("error getting ethernet interfaces from system")
+		}
+		for _, ethernetInterface := range ethernetInterfaces {
+			parseEthernetInterface(ch, SerialNumber, systemManufacturer, system.ID, ethernetInterface)
+		}
+	}
+
+	n.collectorScrapeStatus.WithLabelValues("network").Set(float64(1))
+}
+
+func (n *NetworkCollector) collectNetworkAdapter(ch chan<- prometheus.Metric, SerialNumber, systemManufacturer, chassisID string, networkAdapter *redfishapi.NetworkAdapter, chassisLogContext *log.Entry) {
+	adapterID := networkAdapter.ID
+	adapterModel := networkAdapter.Model
+	networkAdapterLabelValues := []string{SerialNumber, systemManufacturer, "network_adapter", chassisID, adapterID, adapterModel}
+	networkAdapterInfoLabelValues := []string{SerialNumber, systemManufacturer, "network_adapter", chassisID, adapterID, adapterModel, networkAdapter.Manufacturer, networkAdapter.SerialNumber, networkAdapter.FirmwareVersion()}
+
+	if networkAdapterHealthValue, ok := parseCommonStatusHealth(networkAdapter.Status.Health); ok {
+		ch <- prometheus.MustNewConstMetric(n.metrics["network_adapter_health"].desc, prometheus.GaugeValue, networkAdapterHealthValue, networkAdapterLabelValues...)
+	}
+	if networkAdapterStateValue, ok := parseCommonStatusState(networkAdapter.Status.State); ok {
+		ch <- prometheus.MustNewConstMetric(n.metrics["network_adapter_state"].desc, prometheus.GaugeValue, networkAdapterStateValue, networkAdapterLabelValues...)
+	}
+	ch <- prometheus.MustNewConstMetric(n.metrics["network_adapter_info"].desc, prometheus.GaugeValue, float64(1), networkAdapterInfoLabelValues...)
+
+	networkPorts, err := networkAdapter.NetworkPorts()
+	if err != nil {
+		chassisLogContext.WithFields(log.Fields{"operation": "networkAdapter.NetworkPorts()", "adapter": adapterID}).WithError(err).Error("error getting network ports from network adapter")
+	}
+	for _, networkPort := range networkPorts {
+		parseNetworkPort(ch, SerialNumber, systemManufacturer, chassisID, adapterID, networkPort)
+	}
+}
+
+func parseNetworkPort(ch chan<- prometheus.Metric, SerialNumber, systemManufacturer, chassisID, adapterID string, networkPort *redfishapi.NetworkPort) {
+	portID := networkPort.ID
+	if portID == "" {
+		portID = networkPort.PhysicalPortNumber
+	}
+	networkPortLabelValues := []string{SerialNumber, systemManufacturer, "network_port", chassisID, adapterID, portID}
+	networkPortInfoLabelValues := []string{SerialNumber, systemManufacturer, "network_port", chassisID, adapterID, portID, networkPort.MACAddress(), networkPort.ActiveLinkTechnology}
+
+	if networkPortLinkStatusValue, ok := parseNetworkLinkStatus(string(networkPort.LinkStatus)); ok {
+		ch <- prometheus.MustNewConstMetric(networkMetrics["network_port_link_status"].desc, prometheus.GaugeValue, networkPortLinkStatusValue, networkPortLabelValues...)
+	}
+	if networkPortHealthValue, ok := parseCommonStatusHealth(networkPort.Status.Health); ok {
+		ch <- prometheus.MustNewConstMetric(networkMetrics["network_port_health"].desc, prometheus.GaugeValue, networkPortHealthValue, networkPortLabelValues...)
+	}
+	ch <- prometheus.MustNewConstMetric(networkMetrics["network_port_speed_mbps"].desc, prometheus.GaugeValue, float64(networkPort.CurrentLinkSpeedMbps), networkPortLabelValues...)
+	ch <- prometheus.MustNewConstMetric(networkMetrics["network_port_info"].desc, prometheus.GaugeValue, float64(1), networkPortInfoLabelValues...)
+}
+
+func parseEthernetInterface(ch chan<- prometheus.Metric, SerialNumber, systemManufacturer, systemID string, ethernetInterface *redfishapi.EthernetInterface) {
+	macAddress := ethernetInterface.MACAddress
+	if macAddress == "" {
+		macAddress = ethernetInterface.PermanentMACAddress
+	}
+	ethernetInterfaceLabelValues := []string{SerialNumber, systemManufacturer, "ethernet_interface", systemID, ethernetInterface.ID, ethernetInterface.Name}
+	ethernetInterfaceInfoLabelValues := []string{SerialNumber, systemManufacturer, "ethernet_interface", systemID, ethernetInterface.ID, ethernetInterface.Name, macAddress}
+
+	if ethernetInterfaceLinkStatusValue, ok := parseNetworkLinkStatus(string(ethernetInterface.LinkStatus)); ok {
+		ch <- prometheus.MustNewConstMetric(networkMetrics["network_ethernet_interface_link_status"].desc, prometheus.GaugeValue, ethernetInterfaceLinkStatusValue, ethernetInterfaceLabelValues...)
+	}
+	if ethernetInterfaceHealthValue, ok := parseCommonStatusHealth(ethernetInterface.Status.Health); ok {
+		ch <- prometheus.MustNewConstMetric(networkMetrics["network_ethernet_interface_health"].desc, prometheus.GaugeValue, ethernetInterfaceHealthValue, ethernetInterfaceLabelValues...)
+	}
+	if ethernetInterfaceStateValue, ok := parseCommonStatusState(ethernetInterface.Status.State); ok {
+		ch <- prometheus.MustNewConstMetric(networkMetrics["network_ethernet_interface_state"].desc, prometheus.GaugeValue, ethernetInterfaceStateValue, ethernetInterfaceLabelValues...)
+	}
+	ch <- prometheus.MustNewConstMetric(networkMetrics["network_ethernet_interface_speed_mbps"].desc, prometheus.GaugeValue, float64(ethernetInterface.SpeedMbps), ethernetInterfaceLabelValues...)
+	ch <- prometheus.MustNewConstMetric(networkMetrics["network_ethernet_interface_info"].desc, prometheus.GaugeValue, float64(1), ethernetInterfaceInfoLabelValues...)
+}
+
+func parseNetworkLinkStatus(status string) (float64, bool) {
+	switch status {
+	case "Up", "LinkUp":
+		return float64(1), true
+	case "Down", "LinkDown":
+		return float64(2), true
+	case "NoLink":
+		return float64(3), true
+	}
+	return float64(0), false
+}
@@ -0,0 +1,268 @@
+package collector
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/magicst0ne/rackserver_exporter/redfish"
+	"github.com/magicst0ne/rackserver_exporter/redfish/redfishapi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PCIeSubsystem is the pcie subsystem
+var (
+	PCIeSubsystem              = "pcie"
+	PCIeDeviceLabelNames       = []string{"sn", "mfr", "resource", "device_id", "device_name", "slot"}
+	PCIeDeviceInfoLabelNames   = []string{"sn", "mfr", "resource", "device_id", "device_name", "slot", "device_mfr", "model", "device_sn", "part_number", "firmware_version", "pcie_type", "lanes"}
+	PCIeFunctionInfoLabelNames = []string{"sn", "mfr", "resource", "device_id", "function_id", "device_class", "vendor_id", "pci_device_id", "subsystem_vendor_id", "subsystem_id"}
+	PCIeSlotLabelNames         = []string{"sn", "mfr", "resource", "chassis_id", "slot", "slot_type", "pcie_type", "lanes", "device_id"}
+
+	pcieMetrics = map[string]pcieMetric{
+		"pcie_device_health": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, PCIeSubsystem, "device_health"),
+				"health of pcie device,1(OK),2(Warning),3(Critical)",
+				PCIeDeviceLabelNames,
+				nil,
+			),
+		},
+		"pcie_device_state": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, PCIeSubsystem, "device_state"),
+				"state of pcie device,1(Enabled),2(Disabled),3(StandbyOffinline),4(StandbySpare),5(InTest),6(Starting),7(Absent),8(UnavailableOffline),9(Deferring),10(Quiesced),11(Updating)",
+				PCIeDeviceLabelNames,
+				nil,
+			),
+		},
+		"pcie_device_info": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, PCIeSubsystem, "device_info"),
+				"pcie device inventory, value is always 1",
+				PCIeDeviceInfoLabelNames,
+				nil,
+			),
+		},
+		"pcie_function_info": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, PCIeSubsystem, "function_info"),
+				"pcie function vendor and device ids, value is always 1",
+				PCIeFunctionInfoLabelNames,
+				nil,
+			),
+		},
+		"pcie_slot_occupied": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, PCIeSubsystem, "slot_occupied"),
+				"pcie slot has a device installed,1(occupied),0(empty)",
+				PCIeSlotLabelNames,
+				nil,
+			),
+		},
+		"pcie_slot_health": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, PCIeSubsystem, "slot_health"),
+				"health of pcie slot,1(OK),2(Warning),3(Critical)",
+				PCIeSlotLabelNames,
+				nil,
+			),
+		},
+	}
+)
+
+// PCIeCollector implements the prometheus.Collector.
+type PCIeCollector struct {
+	redfishClient         *redfish.APIClient
+	metrics               map[string]pcieMetric
+	collectorScrapeStatus *prometheus.GaugeVec
+	tier                  string
+	Log                   *log.Entry
+}
+
+type pcieMetric struct {
+	desc *prometheus.Desc
+}
+
+// pcieSlot is a PCIe slot of a chassis and the name it is exported with.
+type pcieSlot struct {
+	chassisID string
+	name      string
+	slot      redfishapi.PCIeSlot
+}
+
+// NewPCIeCollector returns a collector that collecting pcie devices and slots.
+// It collects the states and health of the devices and slots for the readings
+// tier, and their inventory for the inventory tier.
+func NewPCIeCollector(namespace string, redfishClient *redfish.APIClient, tier string, logger *log.Entry) *PCIeCollector {
+	return &PCIeCollector{
+		redfishClient: redfishClient,
+		metrics:       pcieMetrics,
+		tier:          tier,
+		Log: logger.WithFields(log.Fields{
+			"collector": "PCIeCollector",
+			"tier":      tier,
+		}),
+		collectorScrapeStatus: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "collector_scrape_status",
+				Help:      "collector_scrape_status",
+			},
+			[]string{"collector"},
+		),
+	}
+}
+
+// Describe implemented prometheus.Collector
+func (p *PCIeCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range p.metrics {
+		ch <- metric.desc
+	}
+	p.collectorScrapeStatus.Describe(ch)
+}
+
+// Collect implemented prometheus.Collector
+func (p *PCIeCollector) Collect(ch chan<- prometheus.Metric) {
+	collectorLogContext := p.Log
+	service := p.redfishClient.Service
+
+	// the same device is often linked from both the system and the chassis
+	var pcieDevices []*redfishapi.PCIeDevice
+	seen := make(map[string]bool)
+	addPCIeDevices := func(devices []*redfishapi.PCIeDevice) {
+		for _, pcieDevice := range devices {
+			if !seen[pcieDevice.ODataID] {
+				seen[pcieDevice.ODataID] = true
+				pcieDevices = append(pcieDevices, pcieDevice)
+			}
+		}
+	}
+
+	systems, err := service.Systems()
+	if err != nil {
+		collectorLogContext.WithField("operation", "service.Systems()").WithError(err).Error("error getting systems from service")
+	}
+	SerialNumber, systemManufacturer := "", "Unknown"
+	if len(systems) > 0 {
+		SerialNumber, systemManufacturer = systemIdentity(systems[0])
+	}
+	for _, system := range systems {
+		systemPCIeDevices, err := system.PCIeDevices()
+		if err != nil {
+			collectorLogContext.WithFields(log.Fields{"operation": "system.PCIeDevices()", "System": system.ID}).WithError(err).Error("error getting pcie devices from system")
+		}
+		addPCIeDevices(systemPCIeDevices)
+	}
+
+	var pcieSlots []pcieSlot
+	if chassises, err := service.Chassis(); err != nil {
+		collectorLogContext.WithField("operation", "service.Chassis()").WithError(err).Error("error getting chassis from service")
+	} else {
+		for _, chassis := range chassises {
+			chassisLogContext := collectorLogContext.WithField("Chassis", chassis.ID)
+
+			chassisPCIeDevices, err := chassis.PCIeDevices()
+			if err != nil {
+				chassisLogContext.WithField("operation", "chassis.PCIeDevices()").WithError(err).Error("error getting pcie devices from chassis")
+			}
+			addPCIeDevices(chassisPCIeDevices)
+
+			chassisPCIeSlots, err := chassis.PCIeSlots()
+			if err != nil {
+				chassisLogContext.WithField("operation", "chassis.PCIeSlots()").WithError(err).Error("error getting pcie slots from chassis")
+			} else if chassisPCIeSlots != nil {
+				for i, slot := range chassisPCIeSlots.Slots {
+					slotName := slot.Location.PartLocation.ServiceLabel
+					if slotName == "" {
+						slotName = strconv.Itoa(i)
+					}
+					pcieSlots = append(pcieSlots, pcieSlot{chassisID: chassis.ID, name: slotName, slot: slot})
+				}
+			}
+		}
+	}
+
+	// join the devices and the slots they are installed in
+	pcieDeviceIDs := make(map[string]string)
+	for _, pcieDevice := range pcieDevices {
+		pcieDeviceIDs[pcieDevice.ODataID] = pcieDevice.ID
+	}
+	pcieDeviceSlots := make(map[string]string)
+	for _, slot := range pcieSlots {
+		for _, pcieDeviceLink := range slot.slot.PCIeDevices() {
+			pcieDeviceSlots[pcieDeviceLink] = slot.name
+		}
+	}
+
+	for _, pcieDevice := range pcieDevices {
+		parsePCIeDevice(ch, SerialNumber, systemManufacturer, pcieDevice, pcieDeviceSlots[pcieDevice.ODataID], p.tier)
+		if p.tier != InventoryTier {
+			continue
+		}
+
+		pcieFunctions, err := pcieDevice.PCIeFunctions()
+		if err != nil {
+			collectorLogContext.WithFields(log.Fields{"operation": "pcieDevice.PCIeFunctions()", "device": pcieDevice.ID}).WithError(err).Error("error getting pcie functions from pcie device")
+		}
+		for _, pcieFunction := range pcieFunctions {
+			parsePCIeFunction(ch, SerialNumber, systemManufacturer, pcieDevice.ID, pcieFunction)
+		}
+	}
+
+	for _, slot := range pcieSlots {
+		var slotDeviceIDs []string
+		for _, pcieDeviceLink := range slot.slot.PCIeDevices() {
+			pcieDeviceID, ok := pcieDeviceIDs[pcieDeviceLink]
+			if !ok {
+				pcieDeviceID = path.Base(pcieDeviceLink)
+			}
+			slotDeviceIDs = append(slotDeviceIDs, pcieDeviceID)
+		}
+		parsePCIeSlot(ch, SerialNumber, systemManufacturer, slot, strings.Join(slotDeviceIDs, ","), p.tier)
+	}
+
+	p.collectorScrapeStatus.WithLabelValues("pcie").Set(float64(1))
+}
+
+func parsePCIeDevice(ch chan<- prometheus.Metric, SerialNumber, systemManufacturer string, pcieDevice *redfishapi.PCIeDevice, slotName, tier string) {
+	pcieDeviceID := pcieDevice.ID
+	pcieDeviceName := pcieDevice.Name
+	pcieInterface := pcieDevice.PCIeInterface
+
+	if tier == InventoryTier {
+		pcieDeviceInfoLabelValues := []string{SerialNumber, systemManufacturer, "pcie_device", pcieDeviceID, pcieDeviceName, slotName, pcieDevice.Manufacturer, pcieDevice.Model, pcieDevice.SerialNumber, pcieDevice.PartNumber, pcieDevice.FirmwareVersion, string(pcieInterface.PCIeType), strconv.Itoa(pcieInterface.LanesInUse)}
+		ch <- prometheus.MustNewConstMetric(pcieMetrics["pcie_device_info"].desc, prometheus.GaugeValue, float64(1), pcieDeviceInfoLabelValues...)
+		return
+	}
+
+	pcieDeviceLabelValues := []string{SerialNumber, systemManufacturer, "pcie_device", pcieDeviceID, pcieDeviceName, slotName}
+	if pcieDeviceHealthValue, ok := parseCommonStatusHealth(pcieDevice.Status.Health); ok {
+		ch <- prometheus.MustNewConstMetric(pcieMetrics["pcie_device_health"].desc, prometheus.GaugeValue, pcieDeviceHealthValue, pcieDeviceLabelValues...)
+	}
+	if pcieDeviceStateValue, ok := parseCommonStatusState(pcieDevice.Status.State); ok {
+		ch <- prometheus.MustNewConstMetric(pcieMetrics["pcie_device_state"].desc, prometheus.GaugeValue, pcieDeviceStateValue, pcieDeviceLabelValues...)
+	}
+}
+
+func parsePCIeFunction(ch chan<- prometheus.Metric, SerialNumber, systemManufacturer, pcieDeviceID string, pcieFunction *redfishapi.PCIeFunction) {
+	pcieFunctionID := pcieFunction.ID
+	if pcieFunctionID == "" {
+		pcieFunctionID = strconv.Itoa(pcieFunction.FunctionID)
+	}
+	pcieFunctionInfoLabelValues := []string{SerialNumber, systemManufacturer, "pcie_function", pcieDeviceID, pcieFunctionID, pcieFunction.DeviceClass, pcieFunction.VendorID, pcieFunction.DeviceID, pcieFunction.SubsystemVendorID, pcieFunction.SubsystemID}
+
+	ch <- prometheus.MustNewConstMetric(pcieMetrics["pcie_function_info"].desc, prometheus.GaugeValue, float64(1), pcieFunctionInfoLabelValues...)
+}
+
+func parsePCIeSlot(ch chan<- prometheus.Metric, SerialNumber, systemManufacturer string, slot pcieSlot, pcieDeviceID, tier string) {
+	pcieSlotLabelValues := []string{SerialNumber, systemManufacturer, "pcie_slot", slot.chassisID, slot.name, slot.slot.SlotType, string(slot.slot.PCIeType), strconv.Itoa(slot.slot.Lanes), pcieDeviceID}
+
+	if tier == InventoryTier {
+		ch <- prometheus.MustNewConstMetric(pcieMetrics["pcie_slot_occupied"].desc, prometheus.GaugeValue, boolToFloat64(slot.slot.Occupied()), pcieSlotLabelValues...)
+		return
+	}
+	if pcieSlotHealthValue, ok := parseCommonStatusHealth(slot.slot.Status.Health); ok {
+		ch <- prometheus.MustNewConstMetric(pcieMetrics["pcie_slot_health"].desc, prometheus.GaugeValue, pcieSlotHealthValue, pcieSlotLabelValues...)
+	}
+}
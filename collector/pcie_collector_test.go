@@ -0,0 +1,99 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apex/log"
+	"github.com/magicst0ne/rackserver_exporter/redfish"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// newPCIeTestServer serves a system with a GPU installed in Slot 1 of its
+// chassis and an onboard NIC, and an empty second slot.
+func newPCIeTestServer() *httptest.Server {
+	resources := map[string]string{
+		"/redfish/v1/":          `{"Systems": {"@odata.id": "/redfish/v1/Systems"}, "Chassis": {"@odata.id": "/redfish/v1/Chassis"}}`,
+		"/redfish/v1/Systems":   `{"Members@odata.count": 1, "Members": [{"@odata.id": "/redfish/v1/Systems/1"}]}`,
+		"/redfish/v1/Chassis":   `{"Members@odata.count": 1, "Members": [{"@odata.id": "/redfish/v1/Chassis/1"}]}`,
+		"/redfish/v1/Systems/1": `{"@odata.id": "/redfish/v1/Systems/1", "Id": "1", "Manufacturer": "Lenovo", "SerialNumber": "SN1", "PCIeDevices": [{"@odata.id": "/redfish/v1/Chassis/1/PCIeDevices/GPU1"}, {"@odata.id": "/redfish/v1/Chassis/1/PCIeDevices/NIC1"}]}`,
+		"/redfish/v1/Chassis/1": `{"@odata.id": "/redfish/v1/Chassis/1", "Id": "1", "PCIeSlots": {"@odata.id": "/redfish/v1/Chassis/1/PCIeSlots"}}`,
+		"/redfish/v1/Chassis/1/PCIeSlots": `{"Slots": [
+			{"Location": {"PartLocation": {"ServiceLabel": "Slot 1"}}, "Status": {"Health": "OK"}, "Links": {"PCIeDevice": [{"@odata.id": "/redfish/v1/Chassis/1/PCIeDevices/GPU1"}]}},
+			{"Status": {"State": "Absent"}}
+		]}`,
+		"/redfish/v1/Chassis/1/PCIeDevices/GPU1": `{"@odata.id": "/redfish/v1/Chassis/1/PCIeDevices/GPU1", "Id": "GPU1", "Name": "GPU", "Manufacturer": "NVIDIA", "Status": {"State": "Enabled", "Health": "Warning"}}`,
+		"/redfish/v1/Chassis/1/PCIeDevices/NIC1": `{"@odata.id": "/redfish/v1/Chassis/1/PCIeDevices/NIC1", "Id": "NIC1", "Name": "NIC", "Manufacturer": "Intel", "Status": {"State": "Enabled", "Health": "OK"}}`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource, ok := resources[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, resource)
+	}))
+}
+
+// TestPCIeCollector tests the devices are labelled with their slot and the
+// slots with their device, and the tiers each export their metrics.
+func TestPCIeCollector(t *testing.T) {
+	server := newPCIeTestServer()
+	defer server.Close()
+
+	client, err := redfish.Connect(redfish.ClientConfig{Endpoint: server.URL, DisableCache: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		tier    string
+		metrics map[string]float64
+	}{
+		{ReadingsTier, map[string]float64{
+			`rackserver_pcie_device_health{device_id="GPU1",slot="Slot 1"}`: 2,
+			`rackserver_pcie_device_state{device_id="GPU1",slot="Slot 1"}`:  1,
+			`rackserver_pcie_device_health{device_id="NIC1",slot=""}`:       1,
+			`rackserver_pcie_device_state{device_id="NIC1",slot=""}`:        1,
+			`rackserver_pcie_slot_health{device_id="GPU1",slot="Slot 1"}`:   1,
+		}},
+		{InventoryTier, map[string]float64{
+			`rackserver_pcie_device_info{device_id="GPU1",slot="Slot 1"}`:   1,
+			`rackserver_pcie_device_info{device_id="NIC1",slot=""}`:         1,
+			`rackserver_pcie_slot_occupied{device_id="GPU1",slot="Slot 1"}`: 1,
+			`rackserver_pcie_slot_occupied{device_id="",slot="1"}`:          0,
+		}},
+	}
+	for _, test := range tests {
+		registry := prometheus.NewPedanticRegistry()
+		registry.MustRegister(NewPCIeCollector(namespace, client, test.tier, log.WithField("test", t.Name())))
+		families, err := registry.Gather()
+		if err != nil {
+			t.Fatalf("%s: %s", test.tier, err)
+		}
+
+		values := map[string]float64{}
+		for _, family := range families {
+			for _, metric := range family.GetMetric() {
+				labels := map[string]string{}
+				for _, label := range metric.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				if labels["sn"] != "SN1" || labels["mfr"] != "Lenovo" {
+					t.Errorf("%s: %s has labels %v", test.tier, family.GetName(), labels)
+				}
+				values[fmt.Sprintf(`%s{device_id=%q,slot=%q}`, family.GetName(), labels["device_id"], labels["slot"])] = metric.GetGauge().GetValue()
+			}
+		}
+		if len(values) != len(test.metrics) {
+			t.Errorf("%s: got metrics %v, want %v", test.tier, values, test.metrics)
+		}
+		for name, want := range test.metrics {
+			if got, ok := values[name]; !ok || got != want {
+				t.Errorf("%s: got %s %v (%t), want %v", test.tier, name, got, ok, want)
+			}
+		}
+	}
+}
@@ -2,7 +2,6 @@ package collector
 
 import (
 	"bytes"
-	"fmt"
 	"sync"
 	"time"
 
@@ -30,39 +29,52 @@ var (
 		"Collector time duration.",
 		nil, nil,
 	)
+	authModeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, exporter, "auth_mode_info"),
+		"Authentication mode used for the target, session, basic or none.",
+		[]string{"mode"}, nil,
+	)
 )
 
 // RedfishCollector collects redfish metrics. It implements prometheus.Collector.
 type RedfishCollector struct {
+	host          string
+	group         string
 	redfishClient *redfish.APIClient
 	collectors    map[string]prometheus.Collector
+	tierCache     *TierCache
 	redfishUp     prometheus.Gauge
 }
 
 // NewRedfishCollector return RedfishCollector
-func NewRedfishCollector(host string, username string, password string, basicauth string, logger *log.Entry) *RedfishCollector {
+func NewRedfishCollector(host, group string, config redfish.ClientConfig, firmwareBaselines map[string]FirmwareBaseline, healthTracker *HealthTracker, tierCache *TierCache, logger *log.Entry) *RedfishCollector {
 	var collectors map[string]prometheus.Collector
 	collectorLogCtx := logger
-	BasicAuth := false
-	if basicauth!="" {
-		BasicAuth = true
-	}
-	redfishClient, err := newRedfishClient(host, username, password, BasicAuth)
-
+	redfishClient, err := redfish.Connect(config)
 
- 	if err != nil {
+	if err != nil {
 		collectorLogCtx.WithError(err).Error("error creating redfish client")
 	} else {
-		chassisCollector := NewChassisCollector(namespace, redfishClient, collectorLogCtx)
-		systemCollector := NewSystemCollector(namespace, redfishClient, collectorLogCtx)
+		health := healthTracker.ForTarget(host)
+		chassisCollector := NewChassisCollector(namespace, redfishClient, health, ReadingsTier, collectorLogCtx)
+		chassisInventoryCollector := NewChassisCollector(namespace, redfishClient, health, InventoryTier, collectorLogCtx)
+		systemCollector := NewSystemCollector(namespace, redfishClient, health, ReadingsTier, collectorLogCtx)
+		systemInventoryCollector := NewSystemCollector(namespace, redfishClient, health, InventoryTier, collectorLogCtx)
+		firmwareCollector := NewFirmwareCollector(namespace, redfishClient, firmwareBaselines, collectorLogCtx)
+		networkCollector := NewNetworkCollector(namespace, redfishClient, collectorLogCtx)
+		pcieCollector := NewPCIeCollector(namespace, redfishClient, ReadingsTier, collectorLogCtx)
+		pcieInventoryCollector := NewPCIeCollector(namespace, redfishClient, InventoryTier, collectorLogCtx)
 
 		//collectors = map[string]prometheus.Collector{"system": systemCollector}
-		collectors = map[string]prometheus.Collector{"chassis": chassisCollector, "system": systemCollector}
+		collectors = map[string]prometheus.Collector{"chassis": chassisCollector, "chassis_inventory": chassisInventoryCollector, "system": systemCollector, "system_inventory": systemInventoryCollector, "firmware": firmwareCollector, "network": networkCollector, "pcie": pcieCollector, "pcie_inventory": pcieInventoryCollector}
 	}
 
 	return &RedfishCollector{
+		host:          host,
+		group:         group,
 		redfishClient: redfishClient,
 		collectors:    collectors,
+		tierCache:     tierCache,
 		redfishUp: prometheus.NewGauge(
 			prometheus.GaugeOpts{
 				Namespace: namespace,
@@ -89,40 +101,74 @@ func (r *RedfishCollector) Collect(ch chan<- prometheus.Metric) {
 	if r.redfishClient != nil {
 		defer r.redfishClient.Logout()
 		r.redfishUp.Set(1)
-		wg := &sync.WaitGroup{}
-		wg.Add(len(r.collectors))
-
-		defer wg.Wait()
-		for _, collector := range r.collectors {
-			go func(collector prometheus.Collector) {
-				defer wg.Done()
-				collector.Collect(ch)
-			}(collector)
-		}
+		ch <- prometheus.MustNewConstMetric(authModeDesc, prometheus.GaugeValue, 1, string(r.redfishClient.AuthMode()))
 	} else {
 		r.redfishUp.Set(0)
 	}
 
+	wg := &sync.WaitGroup{}
+	wg.Add(len(Tiers))
+	for _, tier := range Tiers {
+		go func(tier string) {
+			defer wg.Done()
+			r.collectTier(ch, tier)
+		}(tier)
+	}
+	wg.Wait()
+
 	ch <- r.redfishUp
 	ch <- prometheus.MustNewConstMetric(totalScrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds())
 }
 
-func newRedfishClient(host string, username string, password string, basicauth bool) (*redfish.APIClient, error) {
+// collectTier emits the metrics of the collectors of tier, from the tier
+// cache while they are within the refresh interval of the tier. Readings are
+// never served from the cache while the target is unreachable.
+func (r *RedfishCollector) collectTier(ch chan<- prometheus.Metric, tier string) {
+	if r.redfishClient == nil && tier == ReadingsTier {
+		return
+	}
+	if metrics, age, ok := r.tierCache.get(r.group, r.host, tier); ok {
+		for _, metric := range metrics {
+			ch <- metric
+		}
+		ch <- prometheus.MustNewConstMetric(tierAgeDesc, prometheus.GaugeValue, age.Seconds(), tier)
+		return
+	}
+	if r.redfishClient == nil {
+		return
+	}
 
-	url := fmt.Sprintf("https://%s", host)
+	tierCh := make(chan prometheus.Metric)
+	done := make(chan struct{})
+	var metrics []prometheus.Metric
+	go func() {
+		for metric := range tierCh {
+			metrics = append(metrics, metric)
+			ch <- metric
+		}
+		close(done)
+	}()
 
-	config := redfish.ClientConfig{
-		Endpoint: url,
-		Username: username,
-		Password: password,
-		Insecure: true,
-		BasicAuth: basicauth,
+	wg := &sync.WaitGroup{}
+	for name, collector := range r.collectors {
+		if collectorTiers[name] != tier {
+			continue
+		}
+		wg.Add(1)
+		go func(collector prometheus.Collector) {
+			defer wg.Done()
+			collector.Collect(tierCh)
+		}(collector)
 	}
-	redfishClient, err := redfish.Connect(config)
-	if err != nil {
-		return nil, err
+	wg.Wait()
+	close(tierCh)
+	<-done
+
+	// a tier without metrics failed, it is collected again by the next scrape
+	if len(metrics) > 0 {
+		r.tierCache.store(r.group, r.host, tier, metrics)
 	}
-	return redfishClient, nil
+	ch <- prometheus.MustNewConstMetric(tierAgeDesc, prometheus.GaugeValue, 0, tier)
 }
 
 func parseCommonStatusHealth(status redfishcommon.Health) (float64, bool) {
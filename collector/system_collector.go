@@ -8,6 +8,7 @@ import (
 	"github.com/apex/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/magicst0ne/rackserver_exporter/redfish"
+	redfishcommon "github.com/magicst0ne/rackserver_exporter/redfish/common"
 	"github.com/magicst0ne/rackserver_exporter/redfish/redfishapi"
 )
 
@@ -20,10 +21,13 @@ type systemMetric struct {
 // SystemSubsystem is the system subsystem
 var (
 	SystemSubsystem                   = "system"
-	SystemLabelNames                  = []string{"sn","mfr", "resource", "system_id", "hw_model"}
+	SystemLabelNames                  = []string{"sn","mfr", "resource", "system_id", "hw_model", "chassis_id", "enclosure_sn"}
 	SystemMemoryLabelNames            = []string{"sn","mfr", "resource", "memory", "memory_id"}
-	SystemProcessorLabelNames         = []string{"sn", "resource", "processor_id", "processor_model"}
-	SystemDriveLabelNames             = []string{"sn", "resource", "drive_name", "drive_model"}
+	SystemProcessorLabelNames         = []string{"sn", "resource", "processor_id", "processor_model", "chassis_id", "enclosure_sn"}
+	SystemProcessorInfoLabelNames     = []string{"sn", "resource", "processor_id", "processor_model", "chassis_id", "enclosure_sn", "processor_type", "architecture", "instruction_set", "mfr", "vendor_id", "effective_family", "effective_model", "step", "microcode"}
+	SystemProcessorErrorLabelNames    = []string{"sn", "resource", "processor_id", "processor_model", "chassis_id", "enclosure_sn", "type"}
+	SystemMemoryAlarmLabelNames       = []string{"sn", "mfr", "resource", "memory", "memory_id", "alarm"}
+	SystemDriveLabelNames             = []string{"sn", "resource", "drive_name", "drive_model", "chassis_id", "enclosure_sn"}
 
 	systemMetrics                     = map[string]systemMetric{
 		"system_state": {
@@ -78,7 +82,7 @@ var (
 			desc: prometheus.NewDesc(
 				prometheus.BuildFQName(namespace, SystemSubsystem, "memory_summary_state"),
 				"system memory state,1(Enabled),2(Disabled),3(StandbyOffinline),4(StandbySpare),5(InTest),6(Starting),7(Absent),8(UnavailableOffline),9(Deferring),10(Quiesced),11(Updating)",
-				SystemMemoryLabelNames,
+				SystemLabelNames,
 				nil,
 			),
 		},
@@ -131,6 +135,150 @@ var (
 				nil,
 			),
 		},
+		"system_processor_info": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "processor_info"),
+				"system processor type and identification, value is always 1",
+				SystemProcessorInfoLabelNames,
+				nil,
+			),
+		},
+		"system_processor_max_speed_mhz": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "processor_max_speed_mhz"),
+				"system processor maximum rated clock speed, MHz",
+				SystemProcessorLabelNames,
+				nil,
+			),
+		},
+		"system_processor_max_tdp_watts": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "processor_max_tdp_watts"),
+				"system processor maximum thermal design power, Watts",
+				SystemProcessorLabelNames,
+				nil,
+			),
+		},
+		"system_processor_temperature_celsius": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "processor_temperature_celsius"),
+				"system processor temperature, Celsius",
+				SystemProcessorLabelNames,
+				nil,
+			),
+		},
+		"system_processor_throttling_celsius": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "processor_throttling_celsius"),
+				"system processor margin to the throttling temperature, Celsius",
+				SystemProcessorLabelNames,
+				nil,
+			),
+		},
+		"system_processor_consumed_power_watts": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "processor_consumed_power_watts"),
+				"system processor consumed power, Watts",
+				SystemProcessorLabelNames,
+				nil,
+			),
+		},
+		"system_processor_power_limit_throttle_seconds_total": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "processor_power_limit_throttle_seconds_total"),
+				"system processor time throttled by a power limit since reset, seconds",
+				SystemProcessorLabelNames,
+				nil,
+			),
+		},
+		"system_processor_thermal_limit_throttle_seconds_total": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "processor_thermal_limit_throttle_seconds_total"),
+				"system processor time throttled by a thermal limit since reset, seconds",
+				SystemProcessorLabelNames,
+				nil,
+			),
+		},
+		"system_processor_correctable_errors_total": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "processor_correctable_errors_total"),
+				"system processor correctable error count, type is cache, core or other",
+				SystemProcessorErrorLabelNames,
+				nil,
+			),
+		},
+		"system_processor_uncorrectable_errors_total": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "processor_uncorrectable_errors_total"),
+				"system processor uncorrectable error count, type is cache, core or other",
+				SystemProcessorErrorLabelNames,
+				nil,
+			),
+		},
+		"system_memory_state": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "memory_state"),
+				"system memory dimm state,1(Enabled),2(Disabled),3(StandbyOffinline),4(StandbySpare),5(InTest),6(Starting),7(Absent),8(UnavailableOffline),9(Deferring),10(Quiesced),11(Updating)",
+				SystemMemoryLabelNames,
+				nil,
+			),
+		},
+		"system_memory_health_status": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "memory_health_status"),
+				"system memory dimm health,1(OK),2(Warning),3(Critical)",
+				SystemMemoryLabelNames,
+				nil,
+			),
+		},
+		"system_memory_capacity_mib": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "memory_capacity_mib"),
+				"system memory dimm capacity, MiB",
+				SystemMemoryLabelNames,
+				nil,
+			),
+		},
+		"system_memory_correctable_ecc_errors_total": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "memory_correctable_ecc_errors_total"),
+				"system memory dimm correctable ecc error count over its lifetime",
+				SystemMemoryLabelNames,
+				nil,
+			),
+		},
+		"system_memory_uncorrectable_ecc_errors_total": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "memory_uncorrectable_ecc_errors_total"),
+				"system memory dimm uncorrectable ecc error count over its lifetime",
+				SystemMemoryLabelNames,
+				nil,
+			),
+		},
+		"system_memory_current_period_correctable_ecc_errors": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "memory_current_period_correctable_ecc_errors"),
+				"system memory dimm correctable ecc error count of the current period, it resets with the period",
+				SystemMemoryLabelNames,
+				nil,
+			),
+		},
+		"system_memory_current_period_uncorrectable_ecc_errors": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "memory_current_period_uncorrectable_ecc_errors"),
+				"system memory dimm uncorrectable ecc error count of the current period, it resets with the period",
+				SystemMemoryLabelNames,
+				nil,
+			),
+		},
+		"system_memory_alarm_trip": {
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, SystemSubsystem, "memory_alarm_trip"),
+				"system memory dimm alarm trip was detected,1(tripped),0(not tripped)",
+				SystemMemoryAlarmLabelNames,
+				nil,
+			),
+		},
 		"system_storage_drive_state": {
 			desc: prometheus.NewDesc(
 				prometheus.BuildFQName(namespace, SystemSubsystem, "storage_drive_state"),
@@ -164,16 +312,23 @@ type SystemCollector struct {
 	metrics                 map[string]systemMetric
 	collectorScrapeStatus   *prometheus.GaugeVec
 	collectorScrapeDuration *prometheus.SummaryVec
+	health                  *TargetHealth
+	tier                    string
 	Log                     *log.Entry
 }
 
-// NewSystemCollector returns a collector that collecting memory statistics
-func NewSystemCollector(namespace string, redfishClient *redfish.APIClient, logger *log.Entry) *SystemCollector {
+// NewSystemCollector returns a collector that collecting memory statistics.
+// It collects the states and readings of the systems for the readings tier,
+// and their models, sizes and capacities for the inventory tier.
+func NewSystemCollector(namespace string, redfishClient *redfish.APIClient, health *TargetHealth, tier string, logger *log.Entry) *SystemCollector {
 	return &SystemCollector{
 		redfishClient: redfishClient,
 		metrics:       systemMetrics,
+		health:        health,
+		tier:          tier,
 		Log: logger.WithFields(log.Fields{
 			"collector": "SystemCollector",
+			"tier":      tier,
 		}),
 		collectorScrapeStatus: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
@@ -213,20 +368,10 @@ func (s *SystemCollector) Collect(ch chan<- prometheus.Metric) {
 
 			// server info
 			SystemID := system.ID
-			SerialNumber := system.SerialNumber
+			SerialNumber, systemManufacturer := systemIdentity(system)
 
 			systemModel := system.Model
 
-			systemManufacturer := "Unknown"
-			if system.Manufacturer != "" {
-				tmpStr := strings.Split(system.Manufacturer, " ")
-				systemManufacturer = tmpStr[0]
-			}
-			
-			if systemManufacturer=="Dell" {
-				SerialNumber = system.SKU
-			}
-
 			//common status
 			systemState := system.Status.State
 			systemHealthStatus := system.Status.Health
@@ -242,37 +387,43 @@ func (s *SystemCollector) Collect(ch chan<- prometheus.Metric) {
 			systemMemorySummaryHealthStatus := system.MemorySummary.Status.Health
 			systemMemorySummarySize := system.MemorySummary.TotalSystemMemoryGiB
 
-			systemLabelValues := []string{SerialNumber, systemManufacturer, "system", SystemID, systemModel}
+			// relate the system to its chassis and enclosure, so multi-node
+			// chassis and blades share the labels of the chassis metrics
+			chassisID, enclosureSN := systemTopology(system, service, systemLogContext)
 
-			// system state health
-			if systemStateValue, ok := parseCommonStatusState(systemState); ok {
-				ch <- prometheus.MustNewConstMetric(s.metrics["system_state"].desc, prometheus.GaugeValue, systemStateValue, systemLabelValues...)
-			}
-			if systemHealthStatusValue, ok := parseCommonStatusHealth(systemHealthStatus); ok {
-				ch <- prometheus.MustNewConstMetric(s.metrics["system_health_status"].desc, prometheus.GaugeValue, systemHealthStatusValue, systemLabelValues...)
-			}
-			if systemPowerStateValue, ok := parseCommonPowerState(systemPowerState); ok {
-				ch <- prometheus.MustNewConstMetric(s.metrics["system_power_state"].desc, prometheus.GaugeValue, systemPowerStateValue, systemLabelValues...)
-			}
+			systemLabelValues := []string{SerialNumber, systemManufacturer, "system", SystemID, systemModel, chassisID, enclosureSN}
 
-			// cpu summary
-			if systemProcessorSummaryStateValue, ok := parseCommonStatusState(systemProcessorSummaryState); ok {
-				ch <- prometheus.MustNewConstMetric(s.metrics["system_processor_summary_state"].desc, prometheus.GaugeValue, systemProcessorSummaryStateValue, systemLabelValues...)
-			}
-			if systemProcessorSummaryHealthStatusValue, ok := parseCommonStatusHealth(systemProcessorSummaryHealthStatus); ok {
-				ch <- prometheus.MustNewConstMetric(s.metrics["system_processor_summary_health_status"].desc, prometheus.GaugeValue, systemProcessorSummaryHealthStatusValue, systemLabelValues...)
-			}
-			ch <- prometheus.MustNewConstMetric(s.metrics["system_processor_summary_count"].desc, prometheus.GaugeValue, float64(systemProcessorSummaryCount), systemLabelValues...)
+			if s.tier == InventoryTier {
+				ch <- prometheus.MustNewConstMetric(s.metrics["system_processor_summary_count"].desc, prometheus.GaugeValue, float64(systemProcessorSummaryCount), systemLabelValues...)
+				ch <- prometheus.MustNewConstMetric(s.metrics["system_memory_summary_size"].desc, prometheus.GaugeValue, float64(systemMemorySummarySize), systemLabelValues...)
+			} else {
+				// system state health
+				if systemStateValue, ok := parseCommonStatusState(systemState); ok {
+					ch <- prometheus.MustNewConstMetric(s.metrics["system_state"].desc, prometheus.GaugeValue, systemStateValue, systemLabelValues...)
+				}
+				if systemHealthStatusValue, ok := parseCommonStatusHealth(systemHealthStatus); ok {
+					ch <- prometheus.MustNewConstMetric(s.metrics["system_health_status"].desc, prometheus.GaugeValue, systemHealthStatusValue, systemLabelValues...)
+				}
+				if systemPowerStateValue, ok := parseCommonPowerState(systemPowerState); ok {
+					ch <- prometheus.MustNewConstMetric(s.metrics["system_power_state"].desc, prometheus.GaugeValue, systemPowerStateValue, systemLabelValues...)
+				}
 
-			// mem summary
-			if systemMemorySummaryStateValue, ok := parseCommonStatusState(systemMemorySummaryState); ok {
-				ch <- prometheus.MustNewConstMetric(s.metrics["system_memory_summary_state"].desc, prometheus.GaugeValue, systemMemorySummaryStateValue, systemLabelValues...)
-			}
-			if systemMemorySummaryHealthStatusValue, ok := parseCommonStatusHealth(systemMemorySummaryHealthStatus); ok {
-				ch <- prometheus.MustNewConstMetric(s.metrics["system_memory_summary_health_status"].desc, prometheus.GaugeValue, systemMemorySummaryHealthStatusValue, systemLabelValues...)
-			}
-			ch <- prometheus.MustNewConstMetric(s.metrics["system_memory_summary_size"].desc, prometheus.GaugeValue, float64(systemMemorySummarySize), systemLabelValues...)
+				// cpu summary
+				if systemProcessorSummaryStateValue, ok := parseCommonStatusState(systemProcessorSummaryState); ok {
+					ch <- prometheus.MustNewConstMetric(s.metrics["system_processor_summary_state"].desc, prometheus.GaugeValue, systemProcessorSummaryStateValue, systemLabelValues...)
+				}
+				if systemProcessorSummaryHealthStatusValue, ok := parseCommonStatusHealth(systemProcessorSummaryHealthStatus); ok {
+					ch <- prometheus.MustNewConstMetric(s.metrics["system_processor_summary_health_status"].desc, prometheus.GaugeValue, systemProcessorSummaryHealthStatusValue, systemLabelValues...)
+				}
 
+				// mem summary
+				if systemMemorySummaryStateValue, ok := parseCommonStatusState(systemMemorySummaryState); ok {
+					ch <- prometheus.MustNewConstMetric(s.metrics["system_memory_summary_state"].desc, prometheus.GaugeValue, systemMemorySummaryStateValue, systemLabelValues...)
+				}
+				if systemMemorySummaryHealthStatusValue, ok := parseCommonStatusHealth(systemMemorySummaryHealthStatus); ok {
+					ch <- prometheus.MustNewConstMetric(s.metrics["system_memory_summary_health_status"].desc, prometheus.GaugeValue, systemMemorySummaryHealthStatusValue, systemLabelValues...)
+				}
+			}
 
 			// process processor metrics
 			processors, err := system.Processors()
@@ -285,10 +436,29 @@ func (s *SystemCollector) Collect(ch chan<- prometheus.Metric) {
 				wg2.Add(len(processors))
 
 				for _, processor := range processors {
-					go parsePorcessor(ch, SerialNumber, systemManufacturer, processor, wg2, systemLogContext)
+					if s.tier != InventoryTier {
+						s.health.Observe("processor", system.ID, processor.ID, processor.Status.Health)
+					}
+					go parsePorcessor(ch, SerialNumber, systemManufacturer, chassisID, enclosureSN, processor, s.tier, wg2, systemLogContext)
 				}
+				wg2.Wait()
 			}
 
+			// process memory metrics
+			memories, err := system.Memory()
+			if err != nil {
+				systemLogContext.WithField("operation", "system.Memory()").WithError(err).Error("error getting memory data from system")
+			} else if memories == nil {
+				systemLogContext.WithField("operation", "system.Memory()").Info("no memory data found")
+			} else {
+				wg3 := &sync.WaitGroup{}
+				wg3.Add(len(memories))
+
+				for _, memory := range memories {
+					go parseMemory(ch, SerialNumber, systemManufacturer, memory, s.tier, wg3, systemLogContext)
+				}
+				wg3.Wait()
+			}
 
 			if systemManufacturer=="HPE" {
 				hpStorages, err := system.SmartStorages()
@@ -307,8 +477,12 @@ func (s *SystemCollector) Collect(ch chan<- prometheus.Metric) {
 							wg4 := &sync.WaitGroup{}
 							wg4.Add(len(drives))
 							for _, drive := range drives {
-								go parseHpDrive(ch, SerialNumber, systemManufacturer, drive, wg4, systemLogContext)
+								if s.tier != InventoryTier {
+									s.health.Observe("drive", system.ID, drive.Location, drive.Status.Health)
+								}
+								go parseHpDrive(ch, SerialNumber, systemManufacturer, chassisID, enclosureSN, drive, s.tier, wg4, systemLogContext)
 							}
+							wg4.Wait()
 						}
 					}
 				}
@@ -325,8 +499,12 @@ func (s *SystemCollector) Collect(ch chan<- prometheus.Metric) {
 						wg4 := &sync.WaitGroup{}
 						wg4.Add(len(devices))
 						for _, device := range devices {
-							go parseDellDrive(ch, SerialNumber, systemManufacturer, device, wg4, systemLogContext)
+							if s.tier != InventoryTier {
+								s.health.Observe("drive", system.ID, device.Name, device.Status.Health)
+							}
+							go parseDellDrive(ch, SerialNumber, systemManufacturer, chassisID, enclosureSN, device, s.tier, wg4, systemLogContext)
 						}
+						wg4.Wait()
 					}
 				}
 			}
@@ -339,7 +517,67 @@ func (s *SystemCollector) Collect(ch chan<- prometheus.Metric) {
 	
 }
 
-func parsePorcessor(ch chan<- prometheus.Metric, SerialNumber string, systemManufacturer string, processor *redfishapi.Processor, wg *sync.WaitGroup, systemLogContext *log.Entry) {
+// systemIdentity returns the serial number of system and the first word of
+// its manufacturer. The serial number is the service tag (SKU) for Dell.
+func systemIdentity(system *redfishapi.ComputerSystem) (string, string) {
+	systemManufacturer := "Unknown"
+	if system.Manufacturer != "" {
+		systemManufacturer = strings.Split(system.Manufacturer, " ")[0]
+	}
+	if systemManufacturer == "Dell" {
+		return system.SKU, systemManufacturer
+	}
+	return system.SerialNumber, systemManufacturer
+}
+
+// maxChassisDepth bounds the walk up the ContainedBy links of a chassis.
+const maxChassisDepth = 8
+
+// systemTopology returns the id of the chassis of system and the serial number
+// of the enclosure at the top of its ContainedBy links.
+func systemTopology(system *redfishapi.ComputerSystem, service *redfishapi.Service, systemLogContext *log.Entry) (string, string) {
+	chassises, err := system.Chassis()
+	if err != nil {
+		systemLogContext.WithField("operation", "system.Chassis()").WithError(err).Error("error getting chassis of system")
+	}
+
+	// older services only link the systems from the chassis
+	if len(chassises) == 0 {
+		allChassis, err := service.Chassis()
+		if err != nil {
+			systemLogContext.WithField("operation", "service.Chassis()").WithError(err).Error("error getting chassis from service")
+		}
+		for _, chassis := range allChassis {
+			for _, computerSystemLink := range chassis.ComputerSystemLinks() {
+				if computerSystemLink == system.ODataID {
+					chassises = append(chassises, chassis)
+				}
+			}
+		}
+	}
+
+	if len(chassises) == 0 {
+		return "", ""
+	}
+
+	chassis := chassises[0]
+	enclosure := chassis
+	for depth := 0; depth < maxChassisDepth; depth++ {
+		parent, err := enclosure.ContainedBy()
+		if err != nil {
+			systemLogContext.WithFields(log.Fields{"operation": "chassis.ContainedBy()", "chassis": enclosure.ID}).WithError(err).Error("error getting containing chassis")
+			break
+		}
+		if parent == nil {
+			break
+		}
+		enclosure = parent
+	}
+
+	return chassis.ID, chassisSerialNumber(enclosure)
+}
+
+func parsePorcessor(ch chan<- prometheus.Metric, SerialNumber string, systemManufacturer string, chassisID string, enclosureSN string, processor *redfishapi.Processor, tier string, wg *sync.WaitGroup, systemLogContext *log.Entry) {
 	defer func() {
 		wg.Done()
         // recover from panic caused by writing to a closed channel
@@ -357,19 +595,169 @@ func parsePorcessor(ch chan<- prometheus.Metric, SerialNumber string, systemManu
 	processorState := processor.Status.State
 	processorHealthStatus := processor.Status.Health
 
-	systemProcessorLabelValues := []string{SerialNumber, "processor", processorID, processorModel}
+	systemProcessorLabelValues := []string{SerialNumber, "processor", processorID, processorModel, chassisID, enclosureSN}
 
-	if processorStateValue, ok := parseCommonStatusState(processorState); ok {
-		ch <- prometheus.MustNewConstMetric(systemMetrics["system_processor_state"].desc, prometheus.GaugeValue, processorStateValue, systemProcessorLabelValues...)
-	}
-	if processorHealthStatusValue, ok := parseCommonStatusHealth(processorHealthStatus); ok {
-		ch <- prometheus.MustNewConstMetric(systemMetrics["system_processor_health_status"].desc, prometheus.GaugeValue, processorHealthStatusValue, systemProcessorLabelValues...)
+	if tier != InventoryTier {
+		if processorStateValue, ok := parseCommonStatusState(processorState); ok {
+			ch <- prometheus.MustNewConstMetric(systemMetrics["system_processor_state"].desc, prometheus.GaugeValue, processorStateValue, systemProcessorLabelValues...)
+		}
+		if processorHealthStatusValue, ok := parseCommonStatusHealth(processorHealthStatus); ok {
+			ch <- prometheus.MustNewConstMetric(systemMetrics["system_processor_health_status"].desc, prometheus.GaugeValue, processorHealthStatusValue, systemProcessorLabelValues...)
+		}
+
+		processorMetrics, err := processor.Metrics()
+		if err != nil {
+			systemLogContext.WithFields(log.Fields{"operation": "processor.Metrics()", "processor": processorID}).WithError(err).Error("error getting metrics from processor")
+		} else if processorMetrics != nil {
+			parseProcessorMetrics(ch, systemProcessorLabelValues, processorMetrics, systemLogContext)
+		}
+		return
 	}
+
 	ch <- prometheus.MustNewConstMetric(systemMetrics["system_processor_total_threads"].desc, prometheus.GaugeValue, float64(processorTotalThreads), systemProcessorLabelValues...)
 	ch <- prometheus.MustNewConstMetric(systemMetrics["system_processor_total_cores"].desc, prometheus.GaugeValue, float64(processorTotalCores), systemProcessorLabelValues...)
+
+	// processor type and identification, GPUs and other accelerators are processors too
+	processorType := string(processor.ProcessorType)
+	if processorType == "" {
+		processorType = string(redfishapi.CPUProcessorType)
+	}
+	processorIdentification := processor.ProcessorID
+	systemProcessorInfoLabelValues := []string{SerialNumber, "processor", processorID, processorModel, chassisID, enclosureSN, processorType, processor.ProcessorArchitecture, processor.InstructionSet, processor.Manufacturer, processorIdentification.VendorID, processorIdentification.EffectiveFamily, processorIdentification.EffectiveModel, processorIdentification.Step, processorIdentification.MicrocodeInfo}
+	ch <- prometheus.MustNewConstMetric(systemMetrics["system_processor_info"].desc, prometheus.GaugeValue, float64(1), systemProcessorInfoLabelValues...)
+	if processor.MaxSpeedMHz > 0 {
+		ch <- prometheus.MustNewConstMetric(systemMetrics["system_processor_max_speed_mhz"].desc, prometheus.GaugeValue, float64(processor.MaxSpeedMHz), systemProcessorLabelValues...)
+	}
+	if processor.MaxTDPWatts > 0 {
+		ch <- prometheus.MustNewConstMetric(systemMetrics["system_processor_max_tdp_watts"].desc, prometheus.GaugeValue, float64(processor.MaxTDPWatts), systemProcessorLabelValues...)
+	}
+}
+
+func parseProcessorMetrics(ch chan<- prometheus.Metric, systemProcessorLabelValues []string, processorMetrics *redfishapi.ProcessorMetrics, systemLogContext *log.Entry) {
+	if processorMetrics.TemperatureCelsius != 0 {
+		ch <- prometheus.MustNewConstMetric(systemMetrics["system_processor_temperature_celsius"].desc, prometheus.GaugeValue, float64(processorMetrics.TemperatureCelsius), systemProcessorLabelValues...)
+	}
+	if processorMetrics.ThrottlingCelsius != 0 {
+		ch <- prometheus.MustNewConstMetric(systemMetrics["system_processor_throttling_celsius"].desc, prometheus.GaugeValue, float64(processorMetrics.ThrottlingCelsius), systemProcessorLabelValues...)
+	}
+	if processorMetrics.ConsumedPowerWatt != 0 {
+		ch <- prometheus.MustNewConstMetric(systemMetrics["system_processor_consumed_power_watts"].desc, prometheus.GaugeValue, float64(processorMetrics.ConsumedPowerWatt), systemProcessorLabelValues...)
+	}
+
+	throttleDurations := map[string]string{
+		"system_processor_power_limit_throttle_seconds_total":   processorMetrics.PowerLimitThrottleDuration,
+		"system_processor_thermal_limit_throttle_seconds_total": processorMetrics.ThermalLimitThrottleDuration,
+	}
+	for metric, value := range throttleDurations {
+		if value == "" {
+			continue
+		}
+		duration, err := redfishcommon.ParseDuration(value)
+		if err != nil {
+			systemLogContext.WithField("metric", metric).WithError(err).Error("error parsing processor throttle duration")
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(systemMetrics[metric].desc, prometheus.CounterValue, duration.Seconds(), systemProcessorLabelValues...)
+	}
+
+	errorCounts := []struct {
+		errorType     string
+		correctable   *int64
+		uncorrectable *int64
+	}{
+		{"cache", processorMetrics.CacheMetricsTotal.LifeTime.CorrectableECCErrorCount, processorMetrics.CacheMetricsTotal.LifeTime.UncorrectableECCErrorCount},
+		{"core", processorMetrics.CorrectableCoreErrorCount, processorMetrics.UncorrectableCoreErrorCount},
+		{"other", processorMetrics.CorrectableOtherErrorCount, processorMetrics.UncorrectableOtherErrorCount},
+	}
+	for _, errorCount := range errorCounts {
+		systemProcessorErrorLabelValues := append(append([]string{}, systemProcessorLabelValues...), errorCount.errorType)
+		// only export the counters the BMC reports, a missing count is not zero errors
+		if errorCount.correctable != nil {
+			ch <- prometheus.MustNewConstMetric(systemMetrics["system_processor_correctable_errors_total"].desc, prometheus.CounterValue, float64(*errorCount.correctable), systemProcessorErrorLabelValues...)
+		}
+		if errorCount.uncorrectable != nil {
+			ch <- prometheus.MustNewConstMetric(systemMetrics["system_processor_uncorrectable_errors_total"].desc, prometheus.CounterValue, float64(*errorCount.uncorrectable), systemProcessorErrorLabelValues...)
+		}
+	}
+}
+
+func parseMemory(ch chan<- prometheus.Metric, SerialNumber string, systemManufacturer string, memory *redfishapi.Memory, tier string, wg *sync.WaitGroup, systemLogContext *log.Entry) {
+	defer wg.Done()
+
+	memoryLocator := memory.DeviceLocator
+	if memoryLocator == "" {
+		memoryLocator = memory.Name
+	}
+	memoryID := memory.ID
+	memoryState := memory.Status.State
+	memoryHealthStatus := memory.Status.Health
+
+	systemMemoryLabelValues := []string{SerialNumber, systemManufacturer, "memory", memoryLocator, memoryID}
+
+	if tier == InventoryTier {
+		// empty dimm slots have no capacity
+		if memoryState != redfishcommon.AbsentState {
+			ch <- prometheus.MustNewConstMetric(systemMetrics["system_memory_capacity_mib"].desc, prometheus.GaugeValue, float64(memory.CapacityMiB), systemMemoryLabelValues...)
+		}
+		return
+	}
+
+	if memoryStateValue, ok := parseCommonStatusState(memoryState); ok {
+		ch <- prometheus.MustNewConstMetric(systemMetrics["system_memory_state"].desc, prometheus.GaugeValue, memoryStateValue, systemMemoryLabelValues...)
+	}
+	// empty dimm slots have neither health nor metrics
+	if memoryState == redfishcommon.AbsentState {
+		return
+	}
+	if memoryHealthStatusValue, ok := parseCommonStatusHealth(memoryHealthStatus); ok {
+		ch <- prometheus.MustNewConstMetric(systemMetrics["system_memory_health_status"].desc, prometheus.GaugeValue, memoryHealthStatusValue, systemMemoryLabelValues...)
+	}
+
+	memoryMetrics, err := memory.Metrics()
+	if err != nil {
+		systemLogContext.WithFields(log.Fields{"operation": "memory.Metrics()", "memory": memoryID}).WithError(err).Error("error getting metrics from memory")
+		return
+	} else if memoryMetrics == nil {
+		return
+	}
+
+	// only the lifetime counts are counters, the current period resets.
+	// Only export the counts the BMC reports, a missing count is not zero
+	// errors.
+	errorCounts := []struct {
+		metric    string
+		valueType prometheus.ValueType
+		count     *int64
+	}{
+		{"system_memory_correctable_ecc_errors_total", prometheus.CounterValue, memoryMetrics.LifeTime.CorrectableECCErrorCount},
+		{"system_memory_uncorrectable_ecc_errors_total", prometheus.CounterValue, memoryMetrics.LifeTime.UncorrectableECCErrorCount},
+		{"system_memory_current_period_correctable_ecc_errors", prometheus.GaugeValue, memoryMetrics.CurrentPeriod.CorrectableECCErrorCount},
+		{"system_memory_current_period_uncorrectable_ecc_errors", prometheus.GaugeValue, memoryMetrics.CurrentPeriod.UncorrectableECCErrorCount},
+	}
+	for _, errorCount := range errorCounts {
+		if errorCount.count != nil {
+			ch <- prometheus.MustNewConstMetric(systemMetrics[errorCount.metric].desc, errorCount.valueType, float64(*errorCount.count), systemMemoryLabelValues...)
+		}
+	}
+
+	alarmTrips := memoryMetrics.HealthData.AlarmTrips
+	alarms := map[string]*bool{
+		"address_parity_error":    alarmTrips.AddressParityError,
+		"correctable_ecc_error":   alarmTrips.CorrectableECCError,
+		"spare_block":             alarmTrips.SpareBlock,
+		"temperature":             alarmTrips.Temperature,
+		"uncorrectable_ecc_error": alarmTrips.UncorrectableECCError,
+	}
+	for alarm, tripped := range alarms {
+		if tripped == nil {
+			continue
+		}
+		systemMemoryAlarmLabelValues := append(append([]string{}, systemMemoryLabelValues...), alarm)
+		ch <- prometheus.MustNewConstMetric(systemMetrics["system_memory_alarm_trip"].desc, prometheus.GaugeValue, boolToFloat64(*tripped), systemMemoryAlarmLabelValues...)
+	}
 }
 
-func parseHpDrive(ch chan<- prometheus.Metric, SerialNumber string, systemManufacturer string, drive *redfishapi.Drive, wg *sync.WaitGroup, systemLogContext *log.Entry) {
+func parseHpDrive(ch chan<- prometheus.Metric, SerialNumber string, systemManufacturer string, chassisID string, enclosureSN string, drive *redfishapi.Drive, tier string, wg *sync.WaitGroup, systemLogContext *log.Entry) {
 	defer func() {
 		wg.Done()
         // recover from panic caused by writing to a closed channel
@@ -386,18 +774,21 @@ func parseHpDrive(ch chan<- prometheus.Metric, SerialNumber string, systemManufa
 	driveHealthStatus := drive.Status.Health
 
 
-	systemdriveLabelValues := []string{SerialNumber, "drive", driveName, driveModel}
+	systemdriveLabelValues := []string{SerialNumber, "drive", driveName, driveModel, chassisID, enclosureSN}
 
+	if tier == InventoryTier {
+		ch <- prometheus.MustNewConstMetric(systemMetrics["system_storage_drive_capacity"].desc, prometheus.GaugeValue, float64(driveCapacityGB), systemdriveLabelValues...)
+		return
+	}
 	if driveHealthStatusValue, ok := parseCommonStatusHealth(driveHealthStatus); ok {
 		ch <- prometheus.MustNewConstMetric(systemMetrics["system_storage_drive_health_state"].desc, prometheus.GaugeValue, driveHealthStatusValue, systemdriveLabelValues...)
 
 	}
-	ch <- prometheus.MustNewConstMetric(systemMetrics["system_storage_drive_capacity"].desc, prometheus.GaugeValue, float64(driveCapacityGB), systemdriveLabelValues...)
 
 }
 
 
-func parseDellDrive(ch chan<- prometheus.Metric, SerialNumber string, systemManufacturer string, device redfishapi.Device, wg *sync.WaitGroup, systemLogContext *log.Entry) {
+func parseDellDrive(ch chan<- prometheus.Metric, SerialNumber string, systemManufacturer string, chassisID string, enclosureSN string, device redfishapi.Device, tier string, wg *sync.WaitGroup, systemLogContext *log.Entry) {
 	defer func() {
 		wg.Done()
         // recover from panic caused by writing to a closed channel
@@ -414,12 +805,15 @@ func parseDellDrive(ch chan<- prometheus.Metric, SerialNumber string, systemManu
 	driveHealthStatus := device.Status.Health
 
 
-	systemdriveLabelValues := []string{SerialNumber, "drive", driveName, driveModel}
+	systemdriveLabelValues := []string{SerialNumber, "drive", driveName, driveModel, chassisID, enclosureSN}
 
+	if tier == InventoryTier {
+		ch <- prometheus.MustNewConstMetric(systemMetrics["system_storage_drive_capacity"].desc, prometheus.GaugeValue, float64(driveCapacityGB), systemdriveLabelValues...)
+		return
+	}
 	if driveHealthStatusValue, ok := parseCommonStatusHealth(driveHealthStatus); ok {
 		ch <- prometheus.MustNewConstMetric(systemMetrics["system_storage_drive_health_state"].desc, prometheus.GaugeValue, driveHealthStatusValue, systemdriveLabelValues...)
 
 	}
-	ch <- prometheus.MustNewConstMetric(systemMetrics["system_storage_drive_capacity"].desc, prometheus.GaugeValue, float64(driveCapacityGB), systemdriveLabelValues...)
 
 }
@@ -0,0 +1,117 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apex/log"
+	"github.com/magicst0ne/rackserver_exporter/redfish"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// newSystemTestServer serves a service with a single system in chassis 1.
+func newSystemTestServer() *httptest.Server {
+	resources := map[string]string{
+		"/redfish/v1/":        `{"Systems": {"@odata.id": "/redfish/v1/Systems"}, "Chassis": {"@odata.id": "/redfish/v1/Chassis"}}`,
+		"/redfish/v1/Systems": `{"Members@odata.count": 1, "Members": [{"@odata.id": "/redfish/v1/Systems/1"}]}`,
+		"/redfish/v1/Systems/1": `{
+			"@odata.id": "/redfish/v1/Systems/1", "Id": "1", "Manufacturer": "Lenovo", "Model": "SR650", "SerialNumber": "SN1",
+			"Status": {"State": "Enabled", "Health": "OK"}, "PowerState": "On",
+			"ProcessorSummary": {"Count": 2, "Status": {"State": "Enabled", "Health": "OK"}},
+			"MemorySummary": {"TotalSystemMemoryGiB": 256, "Status": {"State": "Enabled", "Health": "Warning"}},
+			"Processors": {"@odata.id": "/redfish/v1/Systems/1/Processors"},
+			"Memory": {"@odata.id": "/redfish/v1/Systems/1/Memory"},
+			"Links": {"Chassis": [{"@odata.id": "/redfish/v1/Chassis/1"}]}
+		}`,
+		"/redfish/v1/Systems/1/Processors": `{"Members@odata.count": 0, "Members": []}`,
+		"/redfish/v1/Systems/1/Memory":     `{"Members@odata.count": 1, "Members": [{"@odata.id": "/redfish/v1/Systems/1/Memory/1"}]}`,
+		"/redfish/v1/Systems/1/Memory/1": `{
+			"@odata.id": "/redfish/v1/Systems/1/Memory/1", "Id": "1", "DeviceLocator": "DIMM 1", "CapacityMiB": 32768,
+			"Status": {"State": "Enabled", "Health": "OK"}, "Metrics": {"@odata.id": "/redfish/v1/Systems/1/Memory/1/MemoryMetrics"}
+		}`,
+		"/redfish/v1/Systems/1/Memory/1/MemoryMetrics": `{"LifeTime": {"CorrectableECCErrorCount": 3}, "HealthData": {"AlarmTrips": {"Temperature": false}}}`,
+		"/redfish/v1/Chassis/1":                        `{"@odata.id": "/redfish/v1/Chassis/1", "Id": "1", "SerialNumber": "CSN1"}`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource, ok := resources[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, resource)
+	}))
+}
+
+// TestSystemCollector scrapes both tiers of a system, failing if a metric
+// is emitted with labels other than those of its desc.
+func TestSystemCollector(t *testing.T) {
+	server := newSystemTestServer()
+	defer server.Close()
+
+	client, err := redfish.Connect(redfish.ClientConfig{Endpoint: server.URL, DisableCache: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		tier    string
+		metrics map[string]float64
+		missing []string
+	}{
+		{ReadingsTier, map[string]float64{
+			"rackserver_system_state":                               1,
+			"rackserver_system_health_status":                       1,
+			"rackserver_system_processor_summary_state":             1,
+			"rackserver_system_memory_summary_state":                1,
+			"rackserver_system_memory_summary_health_status":        2,
+			"rackserver_system_memory_correctable_ecc_errors_total": 3,
+			"rackserver_system_memory_alarm_trip":                   0,
+		}, []string{
+			// not reported by the BMC
+			"rackserver_system_memory_uncorrectable_ecc_errors_total",
+			"rackserver_system_memory_current_period_correctable_ecc_errors",
+		}},
+		{InventoryTier, map[string]float64{
+			"rackserver_system_processor_summary_count": 2,
+			"rackserver_system_memory_summary_size":     256,
+			"rackserver_system_memory_capacity_mib":     32768,
+		}, nil},
+	}
+	for _, test := range tests {
+		registry := prometheus.NewPedanticRegistry()
+		registry.MustRegister(NewSystemCollector(namespace, client, nil, test.tier, log.WithField("test", t.Name())))
+		families, err := registry.Gather()
+		if err != nil {
+			t.Fatalf("%s: %s", test.tier, err)
+		}
+
+		values := map[string]float64{}
+		for _, family := range families {
+			for _, metric := range family.GetMetric() {
+				labels := map[string]string{}
+				for _, label := range metric.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				if labels["sn"] != "SN1" || labels["mfr"] != "Lenovo" {
+					t.Errorf("%s: %s has labels %v", test.tier, family.GetName(), labels)
+				}
+				if chassisID, ok := labels["chassis_id"]; ok && (chassisID != "1" || labels["enclosure_sn"] != "CSN1") {
+					t.Errorf("%s: %s has labels %v", test.tier, family.GetName(), labels)
+				}
+				values[family.GetName()] = metric.GetGauge().GetValue() + metric.GetCounter().GetValue()
+			}
+		}
+		for name, want := range test.metrics {
+			if got, ok := values[name]; !ok || got != want {
+				t.Errorf("%s: got %s %v (%t), want %v", test.tier, name, got, ok, want)
+			}
+		}
+		for _, name := range test.missing {
+			if got, ok := values[name]; ok {
+				t.Errorf("%s: got %s %v, want none", test.tier, name, got)
+			}
+		}
+	}
+}
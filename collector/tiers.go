@@ -0,0 +1,119 @@
+package collector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Refresh tiers of the collectors.
+const (
+	// ReadingsTier holds the collectors of sensor readings and states.
+	ReadingsTier = "readings"
+	// InventoryTier holds the collectors of hardware and firmware inventory,
+	// which only change when hardware is swapped or updated.
+	InventoryTier = "inventory"
+)
+
+// collectorTiers maps the collectors to their tier. The chassis, system and
+// pcie collectors are split, their inventory parts are separate collectors.
+var collectorTiers = map[string]string{
+	"chassis":           ReadingsTier,
+	"system":            ReadingsTier,
+	"network":           ReadingsTier,
+	"pcie":              ReadingsTier,
+	"chassis_inventory": InventoryTier,
+	"system_inventory":  InventoryTier,
+	"firmware":          InventoryTier,
+	"pcie_inventory":    InventoryTier,
+}
+
+// Tiers are the names of the refresh tiers.
+var Tiers = []string{ReadingsTier, InventoryTier}
+
+var tierAgeDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, exporter, "tier_age_seconds"),
+	"Age of the metrics of the tier, 0 if they were collected by this scrape.",
+	[]string{"tier"}, nil,
+)
+
+type tierEntry struct {
+	tier      string
+	metrics   []prometheus.Metric
+	collected time.Time
+}
+
+// TierCache keeps the metrics of the tiers of every target, which are
+// re-emitted by the scrapes within the refresh interval of the tier.
+type TierCache struct {
+	sync.RWMutex
+	refreshIntervals map[string]time.Duration
+	entries          map[string]*tierEntry
+}
+
+// NewTierCache returns a TierCache refreshing the tiers at refreshIntervals.
+func NewTierCache(refreshIntervals map[string]time.Duration) *TierCache {
+	c := &TierCache{entries: make(map[string]*tierEntry)}
+	c.SetRefreshIntervals(refreshIntervals)
+	return c
+}
+
+// SetRefreshIntervals replaces the refresh intervals of the tiers, e.g.
+// after a config reload. Tiers without an interval are collected on every
+// scrape.
+func (c *TierCache) SetRefreshIntervals(refreshIntervals map[string]time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+	c.refreshIntervals = refreshIntervals
+}
+
+// tierKey keys the cache by group too, the groups of a target may use
+// different credentials and see different resources.
+func tierKey(group, target, tier string) string {
+	return group + "\x00" + target + "\x00" + tier
+}
+
+// get returns the metrics of the tier of target in group if they are within
+// the refresh interval, along with their age.
+func (c *TierCache) get(group, target, tier string) ([]prometheus.Metric, time.Duration, bool) {
+	if c == nil {
+		return nil, 0, false
+	}
+	c.RLock()
+	defer c.RUnlock()
+
+	entry, ok := c.entries[tierKey(group, target, tier)]
+	if !ok {
+		return nil, 0, false
+	}
+	age := time.Since(entry.collected)
+	if age >= c.refreshIntervals[tier] {
+		return nil, 0, false
+	}
+	return entry.metrics, age, true
+}
+
+// store keeps the metrics of the tier of target in group, and drops the
+// expired metrics of the other targets.
+func (c *TierCache) store(group, target, tier string, metrics []prometheus.Metric) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+
+	if c.refreshIntervals[tier] <= 0 {
+		return
+	}
+	for key, entry := range c.entries {
+		if time.Since(entry.collected) >= c.refreshIntervals[entry.tier] {
+			delete(c.entries, key)
+		}
+	}
+	c.entries[tierKey(group, target, tier)] = &tierEntry{
+		tier:      tier,
+		metrics:   metrics,
+		collected: time.Now(),
+	}
+}
@@ -0,0 +1,114 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// countingCollector emits one metric and counts how often it was collected.
+type countingCollector struct {
+	desc        *prometheus.Desc
+	collections int
+}
+
+func newCountingCollector(name string) *countingCollector {
+	return &countingCollector{desc: prometheus.NewDesc(name, name, nil, nil)}
+}
+
+func (c *countingCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *countingCollector) Collect(ch chan<- prometheus.Metric) {
+	c.collections++
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, 1)
+}
+
+func TestTierCache(t *testing.T) {
+	tierCache := NewTierCache(map[string]time.Duration{InventoryTier: time.Hour})
+	chassis := newCountingCollector("chassis")
+	firmware := newCountingCollector("firmware")
+
+	scrape := func() int {
+		r := &RedfishCollector{
+			host:          "10.0.0.1",
+			redfishClient: &redfish.APIClient{},
+			collectors:    map[string]prometheus.Collector{"chassis": chassis, "firmware": firmware},
+			tierCache:     tierCache,
+			redfishUp:     prometheus.NewGauge(prometheus.GaugeOpts{Name: "up", Help: "up"}),
+		}
+		ch := make(chan prometheus.Metric)
+		go func() {
+			r.Collect(ch)
+			close(ch)
+		}()
+		n := 0
+		for range ch {
+			n++
+		}
+		return n
+	}
+
+	// the metrics of both collectors, the auth mode, the tier ages, up and
+	// the duration are emitted by every scrape
+	for i := 0; i < 3; i++ {
+		if n := scrape(); n != 7 {
+			t.Errorf("scrape %d emitted %d metrics, want 7", i, n)
+		}
+	}
+	if chassis.collections != 3 || firmware.collections != 1 {
+		t.Errorf("readings collected %d times and inventory %d times, want 3 and 1", chassis.collections, firmware.collections)
+	}
+
+	tierCache.SetRefreshIntervals(nil)
+	scrape()
+	if firmware.collections != 2 {
+		t.Errorf("inventory collected %d times after its interval was removed, want 2", firmware.collections)
+	}
+}
+
+// TestTierCacheUnreachable tests that cached readings are not served while the
+// target is unreachable, and that the groups of a target are cached apart.
+func TestTierCacheUnreachable(t *testing.T) {
+	tierCache := NewTierCache(map[string]time.Duration{ReadingsTier: time.Hour, InventoryTier: time.Hour})
+	chassis := newCountingCollector("chassis")
+	firmware := newCountingCollector("firmware")
+
+	scrape := func(group string, redfishClient *redfish.APIClient) map[string]bool {
+		r := &RedfishCollector{
+			host:          "10.0.0.1",
+			group:         group,
+			redfishClient: redfishClient,
+			collectors:    map[string]prometheus.Collector{"chassis": chassis, "firmware": firmware},
+			tierCache:     tierCache,
+			redfishUp:     prometheus.NewGauge(prometheus.GaugeOpts{Name: "up", Help: "up"}),
+		}
+		ch := make(chan prometheus.Metric)
+		go func() {
+			r.Collect(ch)
+			close(ch)
+		}()
+		emitted := map[string]bool{}
+		for metric := range ch {
+			emitted[metric.Desc().String()] = true
+		}
+		return emitted
+	}
+
+	scrape("dell", &redfish.APIClient{})
+	emitted := scrape("dell", nil)
+	if emitted[chassis.desc.String()] {
+		t.Errorf("cached readings were emitted while the target is unreachable")
+	}
+	if !emitted[firmware.desc.String()] {
+		t.Errorf("cached inventory was not emitted while the target is unreachable")
+	}
+
+	scrape("lab", &redfish.APIClient{})
+	if chassis.collections != 2 || firmware.collections != 2 {
+		t.Errorf("collected %d and %d times after scraping another group, want 2 and 2", chassis.collections, firmware.collections)
+	}
+}
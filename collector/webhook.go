@@ -0,0 +1,173 @@
+package collector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/apex/log"
+)
+
+const webhookQueueSize = 1000
+
+// WebhookConfig configures the webhooks receiving the health transitions.
+type WebhookConfig struct {
+	URLs          []string      `yaml:"urls"`
+	Retries       int           `yaml:"retries"`
+	RetryInterval time.Duration `yaml:"retry_interval"`
+	Timeout       time.Duration `yaml:"timeout"`
+	// DeadLetterFile receives the transitions which could not be delivered,
+	// one JSON object per line. They are logged if it is not set.
+	DeadLetterFile string `yaml:"dead_letter_file"`
+}
+
+// WebhookNotifier POSTs the health transitions to webhooks. It implements HealthNotifier.
+type WebhookNotifier struct {
+	sync.RWMutex
+	config     WebhookConfig
+	httpClient *http.Client
+	queue      chan *HealthTransition
+	Log        *log.Entry
+}
+
+type deadLetter struct {
+	URL        string            `json:"url"`
+	Error      string            `json:"error"`
+	Transition *HealthTransition `json:"transition"`
+}
+
+// NewWebhookNotifier returns a WebhookNotifier delivering the transitions in the background
+func NewWebhookNotifier(config WebhookConfig, logger *log.Entry) *WebhookNotifier {
+	w := &WebhookNotifier{
+		queue: make(chan *HealthTransition, webhookQueueSize),
+		Log: logger.WithFields(log.Fields{
+			"notifier": "WebhookNotifier",
+		}),
+	}
+	w.SetConfig(config)
+	go w.run()
+
+	return w
+}
+
+// SetConfig replaces the webhook configuration, e.g. after a config reload.
+// Transitions being delivered keep the previous configuration.
+func (w *WebhookNotifier) SetConfig(config WebhookConfig) {
+	if config.RetryInterval == 0 {
+		config.RetryInterval = 10 * time.Second
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	w.Lock()
+	defer w.Unlock()
+	w.config = config
+	w.httpClient = &http.Client{Timeout: config.Timeout}
+}
+
+func (w *WebhookNotifier) currentConfig() (WebhookConfig, *http.Client) {
+	w.RLock()
+	defer w.RUnlock()
+	return w.config, w.httpClient
+}
+
+// Notify queues transition for delivery
+func (w *WebhookNotifier) Notify(transition *HealthTransition) {
+	config, _ := w.currentConfig()
+	if len(config.URLs) == 0 {
+		return
+	}
+
+	select {
+	case w.queue <- transition:
+	default:
+		for _, url := range config.URLs {
+			w.deadLetter(config, url, transition, fmt.Errorf("webhook queue is full"))
+		}
+	}
+}
+
+func (w *WebhookNotifier) run() {
+	for transition := range w.queue {
+		payload, err := json.Marshal(transition)
+		if err != nil {
+			w.Log.WithError(err).Error("error encoding health transition")
+			continue
+		}
+
+		config, httpClient := w.currentConfig()
+		for _, url := range config.URLs {
+			if err := w.deliver(config, httpClient, url, payload); err != nil {
+				w.deadLetter(config, url, transition, err)
+			}
+		}
+	}
+}
+
+func (w *WebhookNotifier) deliver(config WebhookConfig, httpClient *http.Client, url string, payload []byte) error {
+	var err error
+	for attempt := 0; attempt <= config.Retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(config.RetryInterval)
+		}
+		if err = post(httpClient, url, payload); err == nil {
+			return nil
+		}
+		w.Log.WithFields(log.Fields{"url": url, "attempt": attempt + 1}).WithError(err).Warn("error delivering health transition")
+	}
+	return err
+}
+
+func post(httpClient *http.Client, url string, payload []byte) error {
+	resp, err := httpClient.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (w *WebhookNotifier) deadLetter(config WebhookConfig, url string, transition *HealthTransition, err error) {
+	logContext := w.Log.WithFields(log.Fields{
+		"url":        url,
+		"target":     transition.Target,
+		"component":  transition.Component,
+		"resource":   transition.Resource,
+		"id":         transition.ID,
+		"transition": transition.PreviousHealth + "->" + transition.Health,
+	}).WithError(err)
+
+	if config.DeadLetterFile == "" {
+		logContext.Error("dropping undeliverable health transition")
+		return
+	}
+
+	if writeErr := appendDeadLetter(config.DeadLetterFile, &deadLetter{URL: url, Error: err.Error(), Transition: transition}); writeErr != nil {
+		logContext.WithField("dead_letter_error", writeErr.Error()).Error("dropping undeliverable health transition")
+	}
+}
+
+func appendDeadLetter(path string, letter *deadLetter) error {
+	line, err := json.Marshal(letter)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
@@ -1,15 +1,92 @@
 package main
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
+	"net"
+	"net/url"
 	"sync"
+	"time"
 
+	"github.com/magicst0ne/rackserver_exporter/collector"
+	"github.com/magicst0ne/rackserver_exporter/redfish"
+	"github.com/prometheus/client_golang/prometheus"
 	yaml "gopkg.in/yaml.v2"
 )
 
+var (
+	configReloadSuccess = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "rackserver",
+		Subsystem: "exporter",
+		Name:      "config_last_reload_successful",
+		Help:      "rackserver exporter config loaded successfully.",
+	})
+
+	configReloadSeconds = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "rackserver",
+		Subsystem: "exporter",
+		Name:      "config_last_reload_success_timestamp_seconds",
+		Help:      "Timestamp of the last successful configuration reload.",
+	})
+
+	requestQueueWaitSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "rackserver",
+		Subsystem: "exporter",
+		Name:      "request_queue_wait_seconds",
+		Help:      "Time redfish requests waited for the request limits of their target.",
+		Buckets:   []float64{.001, .01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"group"})
+
+	connectionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rackserver",
+		Subsystem: "exporter",
+		Name:      "connections_total",
+		Help:      "Connections redfish requests were sent on, by whether they were reused.",
+	}, []string{"group", "reused"})
+
+	cacheHitsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rackserver",
+		Subsystem: "exporter",
+		Name:      "cache_hits_total",
+		Help:      "Redfish resources served from the response cache.",
+	}, []string{"group"})
+
+	cacheMissesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rackserver",
+		Subsystem: "exporter",
+		Name:      "cache_misses_total",
+		Help:      "Redfish resources downloaded from the BMC.",
+	}, []string{"group"})
+
+	tlsHandshakeSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "rackserver",
+		Subsystem: "exporter",
+		Name:      "tls_handshake_seconds",
+		Help:      "Duration of the TLS handshakes with the BMCs.",
+		Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"group"})
+)
+
 type Config struct {
-	Groups map[string]HostConfig `yaml:"groups"`
+	Groups            map[string]HostConfig                 `yaml:"groups"`
+	FirmwareBaselines map[string]collector.FirmwareBaseline `yaml:"firmware_baselines"`
+	Events            EventsConfig                          `yaml:"events"`
+	HealthWebhooks    collector.WebhookConfig               `yaml:"health_webhooks"`
+	Scrape            ScrapeConfig                          `yaml:"scrape"`
+}
+
+// EventsConfig configures the redfish event subscriptions of the targets.
+type EventsConfig struct {
+	// Destination is the URL of the exporter's event receiver the BMCs push to.
+	Destination string `yaml:"destination"`
+	// Context is sent with every event and checked by the receiver, it is
+	// required with Destination.
+	Context    string   `yaml:"context"`
+	EventTypes []string `yaml:"event_types"`
+	// ServerSentEvents streams the events from the targets instead, for
+	// exporters the BMCs cannot reach.
+	ServerSentEvents bool `yaml:"server_sent_events"`
 }
 
 type SafeConfig struct {
@@ -19,24 +96,227 @@ type SafeConfig struct {
 
 type HostConfig struct {
 	Username string `yaml:"username"`
-	Password string `yaml:"password"`
-	BasicAuth string `yaml:"basicauth"`
+	Password Secret `yaml:"password"`
+	// PasswordFile and PasswordCommand are alternatives to Password, see Credentials.
+	PasswordFile    string   `yaml:"password_file"`
+	PasswordCommand []string `yaml:"password_command"`
+	// AuthMode is session, basic, none or auto, default session.
+	AuthMode string `yaml:"auth_mode"`
+	// BasicAuth is the deprecated predecessor of auth_mode: basic.
+	BasicAuth *bool `yaml:"basicauth"`
+	// Targets are host names, IPv4 or IPv6 addresses, host:port or full
+	// URLs, see redfish.TargetEndpoint.
+	Targets []string `yaml:"targets"`
+	// Scheme and Port are used for the targets which don't set them,
+	// default https and the port of the scheme.
+	Scheme    string    `yaml:"scheme"`
+	Port      int       `yaml:"port"`
+	TLSConfig TLSConfig `yaml:"tls_config"`
+	// MaxConcurrentRequests, RequestsPerSecond and RequestBurst limit the
+	// requests sent to each target of the group by all scrapes, 0 is unlimited.
+	MaxConcurrentRequests int     `yaml:"max_concurrent_requests"`
+	RequestsPerSecond     float64 `yaml:"requests_per_second"`
+	RequestBurst          int     `yaml:"request_burst"`
+	// DisableKeepAlives opens a new connection for every request, for
+	// firmware which mishandles persistent connections.
+	DisableKeepAlives bool `yaml:"disable_keep_alives"`
+	// DisableQueryOptions fetches collections member by member even if the
+	// BMC announces $expand and $select.
+	DisableQueryOptions bool `yaml:"disable_query_options"`
+	// DisableCache stops caching the responses of the BMCs, which are
+	// otherwise revalidated with their ETag.
+	DisableCache bool `yaml:"disable_cache"`
+	// CacheTTL is how long the inventory responses of BMCs without ETags
+	// are reused, 0 gets them on every scrape.
+	CacheTTL time.Duration `yaml:"cache_ttl"`
 }
 
-func (sc *SafeConfig) ReloadConfig(configFile string) error {
-	var c = &Config{}
+// TLSConfig configures the TLS connections to the BMCs of a group.
+type TLSConfig struct {
+	CAFile     string `yaml:"ca_file"`
+	ServerName string `yaml:"server_name"`
+	CertFile   string `yaml:"cert_file"`
+	KeyFile    string `yaml:"key_file"`
+	MinVersion string `yaml:"min_version"`
+	// InsecureSkipVerify defaults to true, as most BMCs use self-signed
+	// certificates, unless ca_file is set.
+	InsecureSkipVerify *bool `yaml:"insecure_skip_verify"`
+}
 
-	yamlFile, err := ioutil.ReadFile(configFile)
+var tlsVersions = map[string]uint16{
+	"TLS10": tls.VersionTLS10,
+	"TLS11": tls.VersionTLS11,
+	"TLS12": tls.VersionTLS12,
+	"TLS13": tls.VersionTLS13,
+}
+
+func (c *TLSConfig) validate() error {
+	if _, ok := tlsVersions[c.MinVersion]; c.MinVersion != "" && !ok {
+		return fmt.Errorf("unknown TLS version %s", c.MinVersion)
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return fmt.Errorf("cert_file and key_file must be set together")
+	}
+	return nil
+}
+
+// insecureSkipVerify reports whether the BMC certificates are not verified.
+func (c *TLSConfig) insecureSkipVerify() bool {
+	if c.InsecureSkipVerify != nil {
+		return *c.InsecureSkipVerify
+	}
+	return c.CAFile == ""
+}
+
+// authMode returns the auth mode of the group, honouring the deprecated basicauth.
+func (hc *HostConfig) authMode() redfish.AuthMode {
+	if hc.AuthMode != "" {
+		return redfish.AuthMode(hc.AuthMode)
+	}
+	if hc.BasicAuth != nil && *hc.BasicAuth {
+		return redfish.AuthModeBasic
+	}
+	return redfish.AuthModeSession
+}
+
+// ClientConfig returns the config used to connect to the redfish service of target.
+func (hc *HostConfig) ClientConfig(target string) (redfish.ClientConfig, error) {
+	endpoint, err := redfish.TargetEndpoint(target, hc.Scheme, hc.Port)
 	if err != nil {
-		return err
+		return redfish.ClientConfig{}, err
 	}
-	if err := yaml.Unmarshal(yamlFile, c); err != nil {
+	username, password, err := hc.Credentials(target)
+	if err != nil {
+		return redfish.ClientConfig{}, err
+	}
+
+	return redfish.ClientConfig{
+		Endpoint:            endpoint,
+		Username:            username,
+		Password:            password,
+		AuthMode:            hc.authMode(),
+		Insecure:            hc.TLSConfig.insecureSkipVerify(),
+		TLSCAFile:           hc.TLSConfig.CAFile,
+		TLSServerName:       hc.TLSConfig.ServerName,
+		TLSCertFile:         hc.TLSConfig.CertFile,
+		TLSKeyFile:          hc.TLSConfig.KeyFile,
+		TLSMinVersion:       tlsVersions[hc.TLSConfig.MinVersion],
+		DisableKeepAlives:   hc.DisableKeepAlives,
+		DisableQueryOptions: hc.DisableQueryOptions,
+		DisableCache:        hc.DisableCache,
+		CacheTTL:            hc.CacheTTL,
+		RequestLimits: redfish.RequestLimits{
+			MaxInFlight:       hc.MaxConcurrentRequests,
+			RequestsPerSecond: hc.RequestsPerSecond,
+			Burst:             hc.RequestBurst,
+		},
+	}, nil
+}
+
+// ReloadConfig loads and validates configFile and replaces the running
+// configuration with it. The running configuration is kept on error.
+func (sc *SafeConfig) ReloadConfig(configFile string) (err error) {
+	defer func() {
+		if err != nil {
+			configReloadSuccess.Set(0)
+		} else {
+			configReloadSuccess.Set(1)
+			configReloadSeconds.SetToCurrentTime()
+		}
+	}()
+
+	c, err := loadConfig(configFile)
+	if err != nil {
 		return err
 	}
+	sc.SetConfig(c)
 
+	return nil
+}
+
+// SetConfig replaces the running configuration with a loaded one.
+func (sc *SafeConfig) SetConfig(c *Config) {
 	sc.Lock()
 	sc.C = c
 	sc.Unlock()
+}
+
+// loadConfig reads and validates configFile.
+func loadConfig(configFile string) (*Config, error) {
+	var c = &Config{}
+
+	yamlFile, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file: %s", err)
+	}
+	if err := yaml.UnmarshalStrict(yamlFile, c); err != nil {
+		return nil, fmt.Errorf("error parsing config file: %s", err)
+	}
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("error validating config file: %s", err)
+	}
+	return c, nil
+}
+
+// validate checks the parts of the configuration yaml cannot.
+func (c *Config) validate() error {
+	for name, hostConfig := range c.Groups {
+		if hostConfig.Username == "" && hostConfig.AuthMode != string(redfish.AuthModeNone) {
+			return fmt.Errorf("group %s: username is required", name)
+		}
+		if err := hostConfig.validateCredentials(); err != nil {
+			return fmt.Errorf("group %s: %s", name, err)
+		}
+		if err := hostConfig.TLSConfig.validate(); err != nil {
+			return fmt.Errorf("group %s: tls_config: %s", name, err)
+		}
+		if hostConfig.AuthMode != "" && !redfish.AuthMode(hostConfig.AuthMode).Valid() {
+			return fmt.Errorf("group %s: auth_mode must be session, basic, none or auto", name)
+		}
+		if hostConfig.AuthMode != "" && hostConfig.BasicAuth != nil {
+			return fmt.Errorf("group %s: basicauth is replaced by auth_mode, set only auth_mode", name)
+		}
+		if hostConfig.Scheme != "" && hostConfig.Scheme != "http" && hostConfig.Scheme != "https" {
+			return fmt.Errorf("group %s: scheme must be http or https", name)
+		}
+		if hostConfig.MaxConcurrentRequests < 0 || hostConfig.RequestsPerSecond < 0 || hostConfig.RequestBurst < 0 {
+			return fmt.Errorf("group %s: max_concurrent_requests, requests_per_second and request_burst must not be negative", name)
+		}
+		if hostConfig.CacheTTL < 0 {
+			return fmt.Errorf("group %s: cache_ttl must not be negative", name)
+		}
+		if hostConfig.Port < 0 || hostConfig.Port > 65535 {
+			return fmt.Errorf("group %s: invalid port %d", name, hostConfig.Port)
+		}
+		for _, target := range hostConfig.Targets {
+			if _, err := redfish.TargetEndpoint(target, hostConfig.Scheme, hostConfig.Port); err != nil {
+				return fmt.Errorf("group %s: %s", name, err)
+			}
+		}
+	}
+
+	if c.Events.Destination != "" {
+		if _, err := url.ParseRequestURI(c.Events.Destination); err != nil {
+			return fmt.Errorf("events: invalid destination: %s", err)
+		}
+		// the receiver is unauthenticated, the context is what it checks
+		if c.Events.Context == "" {
+			return fmt.Errorf("events: context is required with a destination")
+		}
+	}
+
+	if err := c.Scrape.validate(); err != nil {
+		return fmt.Errorf("scrape: %s", err)
+	}
+
+	for _, webhookURL := range c.HealthWebhooks.URLs {
+		if _, err := url.ParseRequestURI(webhookURL); err != nil {
+			return fmt.Errorf("health_webhooks: invalid url: %s", err)
+		}
+	}
+	if c.HealthWebhooks.Retries < 0 || c.HealthWebhooks.RetryInterval < 0 || c.HealthWebhooks.Timeout < 0 {
+		return fmt.Errorf("health_webhooks: retries, retry_interval and timeout must not be negative")
+	}
 
 	return nil
 }
@@ -50,4 +330,69 @@ func (sc *SafeConfig) HostConfigForGroup(group string) (*HostConfig, error) {
 		return &hostConfig, nil
 	}
 	return &HostConfig{}, fmt.Errorf("no credentials found for group %s", group)
-}
\ No newline at end of file
+}
+
+// FirmwareBaselines returns the configured firmware baselines keyed by hardware model.
+func (sc *SafeConfig) FirmwareBaselines() map[string]collector.FirmwareBaseline {
+	sc.RLock()
+	defer sc.RUnlock()
+	return sc.C.FirmwareBaselines
+}
+
+// EventsConfig returns the configured event subscription settings.
+func (sc *SafeConfig) EventsConfig() EventsConfig {
+	sc.RLock()
+	defer sc.RUnlock()
+	return sc.C.Events
+}
+
+// EventTarget returns the configured target an event receiver request is
+// from, the target parameter of the subscription destination if it is a
+// configured target, otherwise the target whose host is the sender address.
+func (sc *SafeConfig) EventTarget(target, remoteHost string) (string, bool) {
+	sc.RLock()
+	defer sc.RUnlock()
+
+	for _, hostConfig := range sc.C.Groups {
+		for _, configuredTarget := range hostConfig.Targets {
+			if target != "" && configuredTarget == target {
+				return configuredTarget, true
+			}
+		}
+	}
+
+	remoteIP := net.ParseIP(remoteHost)
+	if remoteIP == nil {
+		return "", false
+	}
+	for _, hostConfig := range sc.C.Groups {
+		for _, configuredTarget := range hostConfig.Targets {
+			endpoint, err := redfish.TargetEndpoint(configuredTarget, hostConfig.Scheme, hostConfig.Port)
+			if err != nil {
+				continue
+			}
+			u, err := url.Parse(endpoint)
+			if err != nil {
+				continue
+			}
+			if ip := net.ParseIP(u.Hostname()); ip != nil && ip.Equal(remoteIP) {
+				return configuredTarget, true
+			}
+		}
+	}
+	return "", false
+}
+
+// HealthWebhooks returns the configured health transition webhooks.
+func (sc *SafeConfig) HealthWebhooks() collector.WebhookConfig {
+	sc.RLock()
+	defer sc.RUnlock()
+	return sc.C.HealthWebhooks
+}
+
+// ScrapeConfig returns the scrape config.
+func (sc *SafeConfig) ScrapeConfig() ScrapeConfig {
+	sc.RLock()
+	defer sc.RUnlock()
+	return sc.C.Scrape
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// credentialCommandTimeout bounds the run time of a password_command.
+const credentialCommandTimeout = 10 * time.Second
+
+// envReference matches a value which is entirely a ${NAME} reference.
+var envReference = regexp.MustCompile(`^\$\{([A-Za-z_][A-Za-z0-9_]*)\}$`)
+
+// Secret is a string which is never printed or marshaled in clear.
+type Secret string
+
+// String implements fmt.Stringer.
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return "<secret>"
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (s Secret) MarshalYAML() (interface{}, error) {
+	return s.String(), nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s.String() + `"`), nil
+}
+
+// expandEnv resolves value if it is a ${NAME} environment variable reference.
+func expandEnv(value string) (string, error) {
+	match := envReference.FindStringSubmatch(value)
+	if match == nil {
+		return value, nil
+	}
+	expanded, ok := os.LookupEnv(match[1])
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", match[1])
+	}
+	return expanded, nil
+}
+
+// validateCredentials checks at most one password source is configured.
+func (hc *HostConfig) validateCredentials() error {
+	sources := 0
+	for _, set := range []bool{hc.Password != "", hc.PasswordFile != "", len(hc.PasswordCommand) > 0} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("only one of password, password_file and password_command may be set")
+	}
+	return nil
+}
+
+// Credentials resolves the username and password used to connect to target.
+// password_command is run with RACKSERVER_TARGET set to target, so one helper
+// can serve per target secrets.
+func (hc *HostConfig) Credentials(target string) (string, string, error) {
+	username, err := expandEnv(hc.Username)
+	if err != nil {
+		return "", "", fmt.Errorf("error resolving username: %s", err)
+	}
+
+	var password string
+	switch {
+	case hc.PasswordFile != "":
+		content, err := ioutil.ReadFile(hc.PasswordFile)
+		if err != nil {
+			return "", "", fmt.Errorf("error reading password_file: %s", err)
+		}
+		password = strings.TrimRight(string(content), "\r\n")
+	case len(hc.PasswordCommand) > 0:
+		password, err = runPasswordCommand(hc.PasswordCommand, target)
+		if err != nil {
+			return "", "", err
+		}
+	default:
+		password, err = expandEnv(string(hc.Password))
+		if err != nil {
+			return "", "", fmt.Errorf("error resolving password: %s", err)
+		}
+	}
+
+	return username, password, nil
+}
+
+func runPasswordCommand(command []string, target string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), credentialCommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Env = append(os.Environ(), "RACKSERVER_TARGET="+target)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// the output is the secret, never include it in the error
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("error running password_command %s: %s: %s", command[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
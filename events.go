@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	alog "github.com/apex/log"
+	"github.com/magicst0ne/rackserver_exporter/collector"
+	"github.com/magicst0ne/rackserver_exporter/redfish"
+	"github.com/magicst0ne/rackserver_exporter/redfish/redfishapi"
+)
+
+// eventsHandler receives the events pushed by the redfish event service of
+// the targets. The target is taken from the 'target' parameter of the
+// subscription destination, falling back to the address of the sender, and
+// must be a configured target.
+func eventsHandler(eventCollector *collector.EventCollector) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		eventsConfig := sc.EventsConfig()
+		if eventsConfig.Destination == "" || eventsConfig.Context == "" {
+			http.Error(w, "event receiver not configured", http.StatusNotFound)
+			return
+		}
+
+		remoteHost, _, _ := net.SplitHostPort(r.RemoteAddr)
+		target, ok := sc.EventTarget(r.URL.Query().Get("target"), remoteHost)
+		if !ok {
+			rootLoggerCtx.WithField("remote", remoteHost).Warn("rejecting event from unknown target")
+			http.Error(w, "unknown target", http.StatusForbidden)
+			return
+		}
+
+		targetLoggerCtx := rootLoggerCtx.WithFields(alog.Fields{
+			"target": target,
+		})
+
+		var event redfishapi.Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			targetLoggerCtx.WithError(err).Error("error decoding event")
+			http.Error(w, "invalid event payload", http.StatusBadRequest)
+			return
+		}
+
+		if !validEventContext(&event, eventsConfig.Context) {
+			targetLoggerCtx.Warn("rejecting event with unknown subscription context")
+			http.Error(w, "unknown subscription context", http.StatusForbidden)
+			return
+		}
+
+		eventCollector.Observe(target, &event)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// validEventContext checks the event carries the context of our subscriptions.
+// Older services only set the context on the event records.
+func validEventContext(event *redfishapi.Event, context string) bool {
+	if context == "" {
+		return false
+	}
+	if event.Context != "" {
+		return event.Context == context
+	}
+	if len(event.Events) == 0 {
+		return false
+	}
+	for _, record := range event.Events {
+		if record.Context != context {
+			return false
+		}
+	}
+	return true
+}
+
+// subscriptionDestination returns the destination of the subscription of target.
+func subscriptionDestination(destination, target string) (string, error) {
+	u, err := url.Parse(destination)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("target", target)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// isOwnSubscription reports whether the subscription was created by subscribeEvents.
+func isOwnSubscription(subscription *redfishapi.EventDestination, eventsConfig EventsConfig) bool {
+	return subscription.Context == eventsConfig.Context && strings.HasPrefix(subscription.Destination, eventsConfig.Destination)
+}
+
+// subscribeEvents creates an event subscription on every target of the
+// configured groups, or on the targets of group if it is not empty.
+func subscribeEvents(group string) error {
+	eventsConfig := sc.EventsConfig()
+	if eventsConfig.Destination == "" {
+		return fmt.Errorf("no event destination configured")
+	}
+
+	var eventTypes []redfishapi.EventType
+	for _, eventType := range eventsConfig.EventTypes {
+		eventTypes = append(eventTypes, redfishapi.EventType(eventType))
+	}
+
+	return forEachTarget(group, func(target string, eventService *redfishapi.EventService, targetLoggerCtx *alog.Entry) error {
+		destination, err := subscriptionDestination(eventsConfig.Destination, target)
+		if err != nil {
+			return err
+		}
+
+		subscriptions, err := eventService.Subscriptions()
+		if err != nil {
+			return err
+		}
+		for _, subscription := range subscriptions {
+			if isOwnSubscription(subscription, eventsConfig) && subscription.Destination == destination {
+				targetLoggerCtx.WithField("subscription", subscription.ODataID).Info("event subscription already exists")
+				return nil
+			}
+		}
+
+		subscription, err := eventService.CreateEventSubscription(destination, eventsConfig.Context, eventTypes)
+		if err != nil {
+			return err
+		}
+		targetLoggerCtx.WithField("subscription", subscription).Info("event subscription created")
+		return nil
+	})
+}
+
+// unsubscribeEvents deletes the event subscriptions created by subscribeEvents.
+func unsubscribeEvents(group string) error {
+	eventsConfig := sc.EventsConfig()
+
+	return forEachTarget(group, func(target string, eventService *redfishapi.EventService, targetLoggerCtx *alog.Entry) error {
+		subscriptions, err := eventService.Subscriptions()
+		if err != nil {
+			return err
+		}
+		for _, subscription := range subscriptions {
+			if !isOwnSubscription(subscription, eventsConfig) {
+				continue
+			}
+			if err := eventService.DeleteEventSubscription(subscription.ODataID); err != nil {
+				return err
+			}
+			targetLoggerCtx.WithField("subscription", subscription.ODataID).Info("event subscription deleted")
+		}
+		return nil
+	})
+}
+
+// forEachTarget connects to the event service of the targets of the
+// configured groups and calls f for each of them.
+func forEachTarget(group string, f func(target string, eventService *redfishapi.EventService, targetLoggerCtx *alog.Entry) error) error {
+	sc.RLock()
+	groups := sc.C.Groups
+	sc.RUnlock()
+
+	if group != "" {
+		hostConfig, ok := groups[group]
+		if !ok {
+			return fmt.Errorf("no credentials found for group %s", group)
+		}
+		groups = map[string]HostConfig{group: hostConfig}
+	}
+
+	failed := 0
+	for groupName, hostConfig := range groups {
+		for _, target := range hostConfig.Targets {
+			targetLoggerCtx := rootLoggerCtx.WithFields(alog.Fields{
+				"target": target,
+				"group":  groupName,
+			})
+
+			if err := withEventService(target, hostConfig, targetLoggerCtx, f); err != nil {
+				targetLoggerCtx.WithError(err).Error("error updating event subscription")
+				failed++
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d targets failed", failed)
+	}
+	return nil
+}
+
+func withEventService(target string, hostConfig HostConfig, targetLoggerCtx *alog.Entry, f func(target string, eventService *redfishapi.EventService, targetLoggerCtx *alog.Entry) error) error {
+	clientConfig, err := hostConfig.ClientConfig(target)
+	if err != nil {
+		return err
+	}
+
+	redfishClient, err := redfish.Connect(clientConfig)
+	if err != nil {
+		return err
+	}
+	defer redfishClient.Logout()
+
+	eventService, err := redfishClient.Service.EventService()
+	if err != nil {
+		return err
+	}
+	if eventService == nil || !eventService.ServiceEnabled {
+		return fmt.Errorf("event service not available")
+	}
+
+	return f(target, eventService, targetLoggerCtx)
+}
+
+// eventStreams runs the server-sent event streams of the configured targets
+// and restarts them when the configuration is reloaded.
+type eventStreams struct {
+	sync.Mutex
+	eventCollector *collector.EventCollector
+	cancel         context.CancelFunc
+}
+
+func newEventStreams(eventCollector *collector.EventCollector) *eventStreams {
+	return &eventStreams{eventCollector: eventCollector}
+}
+
+// Restart stops the running streams and starts new ones for the current
+// configuration, if it enables server-sent events.
+func (e *eventStreams) Restart() {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.cancel != nil {
+		e.cancel()
+		e.cancel = nil
+	}
+	if !sc.EventsConfig().ServerSentEvents {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	streamEvents(ctx, e.eventCollector)
+}
+
+// streamEvents keeps a server-sent event stream open to every target of the
+// configured groups until ctx is done.
+func streamEvents(ctx context.Context, eventCollector *collector.EventCollector) {
+	sc.RLock()
+	groups := sc.C.Groups
+	sc.RUnlock()
+
+	for groupName, hostConfig := range groups {
+		for _, target := range hostConfig.Targets {
+			target := target
+			targetLoggerCtx := rootLoggerCtx.WithFields(alog.Fields{
+				"target": target,
+				"group":  groupName,
+			})
+
+			clientConfig, err := hostConfig.ClientConfig(target)
+			if err != nil {
+				targetLoggerCtx.WithError(err).Error("error getting credentials, not streaming events")
+				continue
+			}
+
+			stream := redfish.NewEventStream(clientConfig, func(event *redfishapi.Event) {
+				eventCollector.Observe(target, event)
+			})
+			stream.OnError = func(err error) {
+				targetLoggerCtx.WithError(err).Warn("event stream interrupted, reconnecting")
+			}
+
+			go stream.Run(ctx)
+		}
+	}
+}
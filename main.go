@@ -1,23 +1,44 @@
 package main
 
 import (
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
 	alog "github.com/apex/log"
 	"github.com/magicst0ne/rackserver_exporter/collector"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/log"
 	kingpin "gopkg.in/alecthomas/kingpin.v2"
 )
 
 
 var (
-	rootLoggerCtx *alog.Entry
+	rootLoggerCtx   *alog.Entry
+	scrapes         = newScrapeGroup()
+	healthTracker   *collector.HealthTracker
+	webhookNotifier *collector.WebhookNotifier
+	tierCache       *collector.TierCache
+	streams         *eventStreams
+
+	// errCredentials fails the scrapes of targets whose credentials can't be
+	// resolved.
+	errCredentials = errors.New("error getting credentials")
 
 	sc = &SafeConfig{
 		C: &Config{},
 	}
+	wc = &SafeWebConfig{
+		C: &WebConfig{},
+	}
 
 	configFile = kingpin.Flag(
 		"config.file",
@@ -27,6 +48,19 @@ var (
 		"web.listen-address",
 		"Address to listen on for web interface and telemetry.",
 	).Default(":9610").String()
+	webConfigFile = kingpin.Flag(
+		"web.config.file",
+		"Path to configuration file that can enable TLS or authentication.",
+	).Default("").String()
+
+	_             = kingpin.Command("serve", "Run the exporter.").Default()
+	eventsCommand = kingpin.Command("events", "Manage redfish event subscriptions of the configured targets.")
+
+	eventsSubscribeCommand = eventsCommand.Command("subscribe", "Create event subscriptions pushing to events.destination.")
+	eventsSubscribeGroup   = eventsSubscribeCommand.Flag("group", "Only subscribe the targets of this group.").String()
+
+	eventsUnsubscribeCommand = eventsCommand.Command("unsubscribe", "Delete the event subscriptions created by subscribe.")
+	eventsUnsubscribeGroup   = eventsUnsubscribeCommand.Flag("group", "Only unsubscribe the targets of this group.").String()
 )
 
 func init() {
@@ -69,14 +103,51 @@ func metricsHandler() http.HandlerFunc {
 			}
 		}
 
-		targetLoggerCtx.Info(hostConfig.Username)
-		targetLoggerCtx.Info(hostConfig.Password)
+		groupName := ""
+		if ok {
+			groupName = group[0]
+		}
+
+		// the credentials are resolved by the scrape, coalesced scrapes don't
+		// run a password command each
+		key := scrapeKey{target: target, group: groupName}
+		families, err := scrapes.Do(key, sc.ScrapeConfig().Freshness, func() ([]*dto.MetricFamily, error) {
+			clientConfig, err := hostConfig.ClientConfig(target)
+			if err != nil {
+				targetLoggerCtx.WithError(err).Error("error getting credentials")
+				return nil, errCredentials
+			}
+
+			clientConfig.ObserveQueueWait = func(wait time.Duration) {
+				requestQueueWaitSeconds.WithLabelValues(groupName).Observe(wait.Seconds())
+			}
+			clientConfig.ObserveConnection = func(reused bool) {
+				connectionsTotal.WithLabelValues(groupName, strconv.FormatBool(reused)).Inc()
+			}
+			clientConfig.ObserveTLSHandshake = func(duration time.Duration) {
+				tlsHandshakeSeconds.WithLabelValues(groupName).Observe(duration.Seconds())
+			}
+			clientConfig.ObserveCache = func(hit bool) {
+				if hit {
+					cacheHitsTotal.WithLabelValues(groupName).Inc()
+				} else {
+					cacheMissesTotal.WithLabelValues(groupName).Inc()
+				}
+			}
 
-		collector := collector.NewRedfishCollector(target, hostConfig.Username, hostConfig.Password, hostConfig.BasicAuth, targetLoggerCtx)
-		registry.MustRegister(collector)
+			collector := collector.NewRedfishCollector(target, groupName, clientConfig, sc.FirmwareBaselines(), healthTracker, tierCache, targetLoggerCtx)
+			registry.MustRegister(collector)
+			return registry.Gather()
+		})
+		if err == errCredentials {
+			http.Error(w, "error getting credentials", http.StatusInternalServerError)
+			return
+		}
 		gatherers := prometheus.Gatherers{
 			prometheus.DefaultGatherer,
-			registry,
+			prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+				return families, err
+			}),
 		}
 		// Delegate http serving to Prometheus client library, which will call collector.Collect.
 		h := promhttp.HandlerFor(gatherers, promhttp.HandlerOpts{})
@@ -85,18 +156,115 @@ func metricsHandler() http.HandlerFunc {
 	}
 }
 
+// reloadConfig reloads the config and web config files and applies them to
+// the parts of the exporter which keep a copy. Both files are validated
+// before either is applied, so a reload is never half done.
+func reloadConfig() (err error) {
+	defer func() {
+		if err != nil {
+			configReloadSuccess.Set(0)
+		} else {
+			configReloadSuccess.Set(1)
+			configReloadSeconds.SetToCurrentTime()
+		}
+	}()
+
+	c, err := loadConfig(*configFile)
+	if err != nil {
+		return err
+	}
+	var webConfig *WebConfig
+	var webTLSConfig *tls.Config
+	if *webConfigFile != "" {
+		if webConfig, webTLSConfig, err = loadWebConfig(*webConfigFile); err != nil {
+			return err
+		}
+	}
+
+	sc.SetConfig(c)
+	if webConfig != nil {
+		wc.setConfig(webConfig, webTLSConfig)
+	}
+	webhookNotifier.SetConfig(sc.HealthWebhooks())
+	tierCache.SetRefreshIntervals(sc.ScrapeConfig().RefreshIntervals)
+	streams.Restart()
+	return nil
+}
+
 func main() {
 
 	log.AddFlags(kingpin.CommandLine)
 	kingpin.HelpFlag.Short('h')
-	kingpin.Parse()
+	command := kingpin.Parse()
 
 	err := sc.ReloadConfig(*configFile)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	switch command {
+	case eventsSubscribeCommand.FullCommand():
+		if err := subscribeEvents(*eventsSubscribeGroup); err != nil {
+			log.Fatal(err)
+		}
+		return
+	case eventsUnsubscribeCommand.FullCommand():
+		if err := unsubscribeEvents(*eventsUnsubscribeGroup); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	prometheus.MustRegister(configReloadSuccess, configReloadSeconds, requestQueueWaitSeconds, connectionsTotal, tlsHandshakeSeconds, cacheHitsTotal, cacheMissesTotal, scrapesCoalesced)
+
+	webhookNotifier = collector.NewWebhookNotifier(sc.HealthWebhooks(), rootLoggerCtx)
+	healthTracker = collector.NewHealthTracker(webhookNotifier)
+	tierCache = collector.NewTierCache(sc.ScrapeConfig().RefreshIntervals)
+
+	eventCollector := collector.NewEventCollector()
+	prometheus.MustRegister(eventCollector)
+	streams = newEventStreams(eventCollector)
+	streams.Restart()
+
+	hup := make(chan os.Signal, 1)
+	reloadCh := make(chan chan error)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-hup:
+				if err := reloadConfig(); err != nil {
+					log.Errorf("Error reloading config: %s", err)
+				} else {
+					log.Info("Reloaded config file")
+				}
+			case rc := <-reloadCh:
+				if err := reloadConfig(); err != nil {
+					log.Errorf("Error reloading config: %s", err)
+					rc <- err
+				} else {
+					log.Info("Reloaded config file")
+					rc <- nil
+				}
+			}
+		}
+	}()
+
 	http.Handle("/redfish", metricsHandler())
+	http.Handle("/events", eventsHandler(eventCollector))
+	http.HandleFunc("/-/reload", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			fmt.Fprintf(w, "This endpoint requires a POST request.\n")
+			return
+		}
+
+		rc := make(chan error)
+		reloadCh <- rc
+		if err := <-rc; err != nil {
+			http.Error(w, fmt.Sprintf("failed to reload config: %s", err), http.StatusInternalServerError)
+		}
+	})
 	http.Handle("/metrics", promhttp.Handler())
 
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
@@ -116,8 +284,14 @@ func main() {
             </html>`))
 	})
 
-	log.Info("app started. listening on ", *listenAddress)
-	err = http.ListenAndServe(*listenAddress, nil)
+	if *webConfigFile != "" {
+		if err := wc.ReloadConfig(*webConfigFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	log.Info("app started. listening on ", *listenAddress, ", TLS: ", wc.TLSEnabled())
+	err = wc.listenAndServe(*listenAddress, http.DefaultServeMux)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,135 @@
+package redfish
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// AuthMode is the way the APIClient authenticates to the service.
+type AuthMode string
+
+const (
+	// AuthModeSession creates a session and sends its token.
+	AuthModeSession AuthMode = "session"
+	// AuthModeBasic sends the credentials with every request.
+	AuthModeBasic AuthMode = "basic"
+	// AuthModeNone sends no credentials.
+	AuthModeNone AuthMode = "none"
+	// AuthModeAuto creates a session and falls back to basic auth if the
+	// service rejects the session creation.
+	AuthModeAuto AuthMode = "auto"
+)
+
+// Valid reports whether mode is a known AuthMode.
+func (mode AuthMode) Valid() bool {
+	switch mode {
+	case AuthModeSession, AuthModeBasic, AuthModeNone, AuthModeAuto:
+		return true
+	}
+	return false
+}
+
+// autoAuthModes holds the mode that worked for an endpoint and user in
+// auto mode, so services without sessions are not asked for one on every
+// connect.
+var autoAuthModes sync.Map
+
+func autoAuthModeKey(config *ClientConfig) string {
+	return config.Endpoint + "\x00" + config.Username
+}
+
+// AutoAuthMode returns the mode recorded by auto mode for endpoint and
+// username, or an empty AuthMode if none was recorded yet.
+func AutoAuthMode(endpoint, username string) AuthMode {
+	if mode, ok := autoAuthModes.Load(endpoint + "\x00" + username); ok {
+		return mode.(AuthMode)
+	}
+	return ""
+}
+
+// authMode returns the configured mode, falling back to BasicAuth.
+func (config *ClientConfig) authMode() AuthMode {
+	if config.AuthMode != "" {
+		return config.AuthMode
+	}
+	if config.BasicAuth {
+		return AuthModeBasic
+	}
+	return AuthModeSession
+}
+
+// setupClientAuth setups the authentication in the client using the client config
+func (c *APIClient) setupClientAuth(config *ClientConfig) error {
+	if config.Session != nil {
+		c.auth = &common.AuthToken{
+			Session: config.Session.ID,
+			Token:   config.Session.Token,
+		}
+		c.authMode = AuthModeSession
+		return nil
+	}
+
+	mode := config.authMode()
+	if config.Username == "" {
+		mode = AuthModeNone
+	}
+
+	switch mode {
+	case AuthModeNone:
+	case AuthModeBasic:
+		c.useBasicAuth(config)
+	case AuthModeSession:
+		auth, err := c.Service.CreateSession(config.Username, config.Password)
+		if err != nil {
+			return err
+		}
+		c.auth = auth
+	case AuthModeAuto:
+		if AutoAuthMode(config.Endpoint, config.Username) == AuthModeBasic {
+			c.useBasicAuth(config)
+			return nil
+		}
+
+		auth, err := c.Service.CreateSession(config.Username, config.Password)
+		if err == nil && auth.Token != "" {
+			c.auth = auth
+			c.authMode = AuthModeSession
+			autoAuthModes.Store(autoAuthModeKey(config), AuthModeSession)
+			return nil
+		}
+		if err == nil && auth.Session != "" {
+			_ = c.Service.DeleteSession(auth.Session)
+		}
+		// a service which can't be reached won't accept basic auth either
+		var httpErr *common.Error
+		if err != nil && !errors.As(err, &httpErr) {
+			return err
+		}
+
+		c.useBasicAuth(config)
+		autoAuthModes.Store(autoAuthModeKey(config), AuthModeBasic)
+		return nil
+	default:
+		return errors.New("unknown auth mode " + string(mode))
+	}
+
+	c.authMode = mode
+	return nil
+}
+
+func (c *APIClient) useBasicAuth(config *ClientConfig) {
+	c.auth = &common.AuthToken{
+		Username:  config.Username,
+		Password:  config.Password,
+		BasicAuth: true,
+	}
+	c.authMode = AuthModeBasic
+}
+
+// AuthMode returns the authentication mode the client uses, which is
+// session or basic for clients connected in auto mode.
+func (c *APIClient) AuthMode() AuthMode {
+	return c.authMode
+}
@@ -0,0 +1,76 @@
+package redfish
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newAuthTestServer(sessions bool, sessionPosts *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/redfish/v1/" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"@odata.id": "/redfish/v1/", "Links": {"Sessions": {"@odata.id": "/redfish/v1/SessionService/Sessions"}}}`))
+		case r.URL.Path == "/redfish/v1/SessionService/Sessions" && r.Method == http.MethodPost:
+			atomic.AddInt32(sessionPosts, 1)
+			if !sessions {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.Header().Set("X-Auth-Token", "token")
+			w.Header().Set("Location", "/redfish/v1/SessionService/Sessions/1")
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestAutoAuthMode(t *testing.T) {
+	for _, sessions := range []bool{true, false} {
+		var sessionPosts int32
+		server := newAuthTestServer(sessions, &sessionPosts)
+
+		want := AuthModeBasic
+		if sessions {
+			want = AuthModeSession
+		}
+
+		config := ClientConfig{Endpoint: server.URL, Username: "root", Password: "calvin", AuthMode: AuthModeAuto}
+		for i := 0; i < 2; i++ {
+			c, err := Connect(config)
+			if err != nil {
+				t.Fatalf("sessions %t: %s", sessions, err)
+			}
+			if c.AuthMode() != want {
+				t.Errorf("sessions %t: got auth mode %s, want %s", sessions, c.AuthMode(), want)
+			}
+		}
+		if got := AutoAuthMode(server.URL, "root"); got != want {
+			t.Errorf("sessions %t: recorded auth mode %s, want %s", sessions, got, want)
+		}
+
+		// the fallback is remembered, the session is created on every connect
+		wantPosts := int32(1)
+		if sessions {
+			wantPosts = 2
+		}
+		if sessionPosts != wantPosts {
+			t.Errorf("sessions %t: got %d session posts, want %d", sessions, sessionPosts, wantPosts)
+		}
+
+		server.Close()
+	}
+}
+
+func TestSessionAuthModeDoesNotFallBack(t *testing.T) {
+	var sessionPosts int32
+	server := newAuthTestServer(false, &sessionPosts)
+	defer server.Close()
+
+	config := ClientConfig{Endpoint: server.URL, Username: "root", Password: "calvin", AuthMode: AuthModeSession}
+	if _, err := Connect(config); err == nil {
+		t.Error("expected the rejected session to fail the connect")
+	}
+}
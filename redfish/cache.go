@@ -0,0 +1,161 @@
+package redfish
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// maxCacheEntries is the number of resources cached per endpoint and
+// credentials, the cache is emptied when it grows beyond.
+const maxCacheEntries = 4096
+
+// cacheEntry is a cached response to a GET.
+type cacheEntry struct {
+	etag   string
+	header http.Header
+	body   []byte
+	stored time.Time
+}
+
+func (e *cacheEntry) response() *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     e.header.Clone(),
+		Body:       io.NopCloser(bytes.NewReader(e.body)),
+	}
+}
+
+// responseCache holds the responses of an endpoint by their URL.
+type responseCache struct {
+	mutex   sync.RWMutex
+	entries map[string]*cacheEntry
+}
+
+// responseCaches holds the responseCache of every endpoint and credentials,
+// shared by the clients connected with them.
+var (
+	responseCachesMutex sync.Mutex
+	responseCaches      = make(map[string]*responseCache)
+)
+
+// responseCacheKey identifies the endpoint and credentials of config. The
+// responses are not shared between users, services can hide resources and
+// properties from some of them.
+func responseCacheKey(config *ClientConfig) string {
+	identity := config.Username
+	if config.Session != nil && identity == "" {
+		identity = "session " + config.Session.ID
+	}
+	return config.Endpoint + "\x00" + string(config.authMode()) + "\x00" + identity
+}
+
+func cacheFor(config *ClientConfig) *responseCache {
+	responseCachesMutex.Lock()
+	defer responseCachesMutex.Unlock()
+
+	key := responseCacheKey(config)
+	cache, ok := responseCaches[key]
+	if !ok {
+		cache = &responseCache{entries: make(map[string]*cacheEntry)}
+		responseCaches[key] = cache
+	}
+	return cache
+}
+
+func (rc *responseCache) get(key string) *cacheEntry {
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+	return rc.entries[key]
+}
+
+func (rc *responseCache) store(key string, entry *cacheEntry) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	if len(rc.entries) >= maxCacheEntries {
+		rc.entries = make(map[string]*cacheEntry)
+	}
+	rc.entries[key] = entry
+}
+
+func (rc *responseCache) delete(key string) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	delete(rc.entries, key)
+}
+
+// volatileResource reports whether the resource at uri holds readings,
+// which are only cached while their ETag is unchanged.
+func volatileResource(uri string) bool {
+	path := strings.TrimSuffix(strings.SplitN(uri, "?", 2)[0], "/")
+	if strings.Contains(path, "/Sensors") || strings.Contains(path, "/ThermalSubsystem") || strings.Contains(path, "/PowerSubsystem") {
+		return true
+	}
+	last := path[strings.LastIndex(path, "/")+1:]
+	return last == "Thermal" || last == "Power" || strings.HasSuffix(last, "Metrics")
+}
+
+// cachedGet gets the resource at uri, revalidating a cached response with
+// its ETag or, for resources without one, reusing it for the cache TTL.
+func (c *APIClient) cachedGet(uri string) (*http.Response, error) {
+	if uri == "" {
+		uri = common.DefaultServiceRoot
+	}
+	key := c.resolve(uri)
+
+	var headers map[string]string
+	if entry := c.cache.get(key); entry != nil {
+		if entry.etag != "" {
+			headers = map[string]string{"If-None-Match": entry.etag}
+		} else if c.cacheTTL > 0 && !volatileResource(uri) && time.Since(entry.stored) < c.cacheTTL {
+			c.observeCacheResult(true)
+			return entry.response(), nil
+		}
+	}
+
+	resp, err := c.runRequestWithHeaders(http.MethodGet, uri, nil, headers)
+	if err != nil {
+		var httpErr *common.Error
+		if headers != nil && errors.As(err, &httpErr) && httpErr.HTTPReturnedStatusCode == http.StatusNotModified {
+			if entry := c.cache.get(key); entry != nil {
+				c.observeCacheResult(true)
+				return entry.response(), nil
+			}
+		}
+		return nil, err
+	}
+	c.observeCacheResult(false)
+
+	etag := resp.Header.Get("ETag")
+	if etag == "" && (c.cacheTTL <= 0 || volatileResource(uri)) {
+		c.cache.delete(key)
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	c.cache.store(key, &cacheEntry{
+		etag:   etag,
+		header: resp.Header.Clone(),
+		body:   body,
+		stored: time.Now(),
+	})
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+func (c *APIClient) observeCacheResult(hit bool) {
+	if c.observeCache != nil {
+		c.observeCache(hit)
+	}
+}
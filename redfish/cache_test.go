@@ -0,0 +1,127 @@
+package redfish
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCache(t *testing.T) {
+	var downloads int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/1/Processors/CPU1":
+			w.Header().Set("ETag", `W/"cpu1-v1"`)
+			if r.Header.Get("If-None-Match") == `W/"cpu1-v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		case "/redfish/v1/Systems/1", "/redfish/v1/Chassis/1/Thermal":
+		case "/redfish/v1/":
+			_, _ = w.Write([]byte(`{}`))
+			return
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		atomic.AddInt32(&downloads, 1)
+		_, _ = w.Write([]byte(`{"@odata.id": "` + r.URL.Path + `"}`))
+	}))
+	defer server.Close()
+
+	var hits, misses int32
+	config := ClientConfig{
+		Endpoint: server.URL,
+		CacheTTL: time.Minute,
+		ObserveCache: func(hit bool) {
+			if hit {
+				atomic.AddInt32(&hits, 1)
+			} else {
+				atomic.AddInt32(&misses, 1)
+			}
+		},
+	}
+
+	get := func(c *APIClient, uri string) {
+		resp, err := c.Get(uri)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != `{"@odata.id": "`+uri+`"}` {
+			t.Errorf("got %s for %s", body, uri)
+		}
+	}
+
+	// the cache is shared by the clients of every scrape
+	for i := 0; i < 3; i++ {
+		c, err := Connect(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		get(c, "/redfish/v1/Systems/1/Processors/CPU1")
+		get(c, "/redfish/v1/Systems/1")
+		get(c, "/redfish/v1/Chassis/1/Thermal")
+	}
+
+	// CPU1 and the system are downloaded once, the readings every time
+	if downloads != 5 {
+		t.Errorf("got %d downloads, want 5", downloads)
+	}
+	// the service root is fetched on every connect and cached by TTL too
+	if hits != 6 || misses != 6 {
+		t.Errorf("got %d hits and %d misses, want 6 and 6", hits, misses)
+	}
+}
+
+func TestVolatileResource(t *testing.T) {
+	for uri, want := range map[string]bool{
+		"/redfish/v1/Chassis/1/Thermal":                          true,
+		"/redfish/v1/Chassis/1/Power/":                           true,
+		"/redfish/v1/Chassis/1/Sensors/Temp1":                    true,
+		"/redfish/v1/Chassis/1/ThermalSubsystem/Fans/1":          true,
+		"/redfish/v1/Systems/1/Processors/CPU1/ProcessorMetrics": true,
+		"/redfish/v1/Systems/1/Processors/CPU1":                  false,
+		"/redfish/v1/Systems/1/Memory?$expand=.":                 false,
+		"/redfish/v1/Chassis/1/PowerSupplies":                    false,
+	} {
+		if got := volatileResource(uri); got != want {
+			t.Errorf("volatileResource(%q) = %t, want %t", uri, got, want)
+		}
+	}
+}
+
+func TestResponseCacheCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redfish/v1/" {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		username, _, _ := r.BasicAuth()
+		_, _ = w.Write([]byte(`{"Name": "` + username + `"}`))
+	}))
+	defer server.Close()
+
+	// every user gets the resources as the service serves them to it
+	for i := 0; i < 2; i++ {
+		for _, username := range []string{"admin", "monitor"} {
+			c, err := Connect(ClientConfig{Endpoint: server.URL, Username: username, Password: "secret", AuthMode: AuthModeBasic, CacheTTL: time.Minute})
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := c.Get("/redfish/v1/Systems/1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if string(body) != `{"Name": "`+username+`"}` {
+				t.Errorf("got %s for %s", body, username)
+			}
+		}
+	}
+}
@@ -8,12 +8,14 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/http/httptrace"
 	"net/http/httputil"
 	"os"
 	"path/filepath"
@@ -38,6 +40,10 @@ type APIClient struct {
 	// Endpoint is the URL of the *fish service
 	endpoint string
 
+	// pathPrefix is the path of the endpoint URL, prepended to the
+	// @odata.id links followed through a reverse proxy.
+	pathPrefix string
+
 	// HTTPClient is for direct http actions
 	HTTPClient *http.Client
 
@@ -49,6 +55,33 @@ type APIClient struct {
 
 	// dumpWriter will receive HTTP dumps if non-nil.
 	dumpWriter io.Writer
+
+	// authMode is the authentication mode in use.
+	authMode AuthMode
+
+	// limiter is shared by the clients of the endpoint, nil if unlimited.
+	limiter *requestLimiter
+
+	// observeQueueWait receives the time requests waited for the limiter.
+	observeQueueWait func(time.Duration)
+
+	// disableKeepAlives closes the connection after every request.
+	disableKeepAlives bool
+
+	// disableQueryOptions keeps the client from using $expand and $select.
+	disableQueryOptions bool
+
+	// cache holds the responses of the endpoint, nil if disabled.
+	cache    *responseCache
+	cacheTTL time.Duration
+
+	// observeCache receives whether Get was served from the cache.
+	observeCache func(hit bool)
+
+	// observeConnection and observeTLSHandshake receive the connections of
+	// the requests.
+	observeConnection   func(reused bool)
+	observeTLSHandshake func(time.Duration)
 }
 
 // Session holds the session ID and auth token needed to identify an
@@ -60,7 +93,8 @@ type Session struct {
 
 // ClientConfig holds the settings for establishing a connection.
 type ClientConfig struct {
-	// Endpoint is the URL of the redfish service
+	// Endpoint is the URL of the redfish service, see TargetEndpoint. Its
+	// path is used as the prefix of the service's links.
 	Endpoint string
 
 	// Username is the optional user name to authenticate with.
@@ -76,17 +110,75 @@ type ClientConfig struct {
 	// Insecure controls whether to enforce SSL certificate validity.
 	Insecure bool
 
+	// TLSCAFile is an optional PEM bundle of the CAs the service certificate
+	// is verified against instead of the system roots.
+	TLSCAFile string
+
+	// TLSServerName overrides the name the service certificate is verified
+	// against, for services addressed by IP.
+	TLSServerName string
+
+	// TLSCertFile and TLSKeyFile are an optional client certificate and key
+	// presented to the service.
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// TLSMinVersion is the minimum TLS version accepted, e.g. tls.VersionTLS12.
+	TLSMinVersion uint16
+
 	// Controls TLS handshake timeout
 	TLSHandshakeTimeout int
 
+	// DisableKeepAlives closes the connection after every request, for
+	// firmware which mishandles persistent connections. By default the
+	// connections are kept open and shared by the clients of the endpoint.
+	DisableKeepAlives bool
+
+	// DisableQueryOptions fetches every member of a collection on its own,
+	// for services which announce $expand or $select but mishandle them.
+	DisableQueryOptions bool
+
+	// DisableCache gets every resource from the service. By default the
+	// responses are cached by URL, shared by the clients of the endpoint,
+	// and revalidated with If-None-Match.
+	DisableCache bool
+
+	// CacheTTL is how long the responses without an ETag are reused, except
+	// for readings such as Thermal, Power, Sensors and metrics. 0 disables
+	// caching them.
+	CacheTTL time.Duration
+
+	// ObserveCache is optionally called for every Get with whether it was
+	// served from the cache.
+	ObserveCache func(hit bool)
+
+	// ObserveConnection is optionally called for every request with whether
+	// it reused an open connection.
+	ObserveConnection func(reused bool)
+
+	// ObserveTLSHandshake is optionally called with the duration of every
+	// TLS handshake.
+	ObserveTLSHandshake func(time.Duration)
+
 	// HTTPClient is the optional client to connect with.
 	HTTPClient *http.Client
 
 	// DumpWriter is an optional io.Writer to receive dumps of HTTP
-	// requests and responses.
+	// requests and responses, with credentials and tokens redacted.
 	DumpWriter io.Writer
 
+	// RequestLimits limits the requests sent to the endpoint by all clients.
+	RequestLimits RequestLimits
+
+	// ObserveQueueWait is optionally called with the time every request
+	// waited for RequestLimits.
+	ObserveQueueWait func(time.Duration)
+
+	// AuthMode selects how the APIClient authenticates, see AuthMode.
+	AuthMode AuthMode
+
 	// BasicAuth tells the APIClient if basic auth should be used (true) or token based auth must be used (false)
+	// when AuthMode is not set.
 	BasicAuth bool
 }
 
@@ -96,28 +188,40 @@ func setupClientWithConfig(ctx context.Context, config *ClientConfig) (c *APICli
 		return c, fmt.Errorf("endpoint must starts with http or https")
 	}
 
+	endpoint, pathPrefix, err := splitEndpoint(config.Endpoint)
+	if err != nil {
+		return c, err
+	}
+
 	client := &APIClient{
-		endpoint:   config.Endpoint,
+		endpoint:   endpoint,
+		pathPrefix: pathPrefix,
 		dumpWriter: config.DumpWriter,
 		ctx:        ctx,
+
+		limiter:          limiterFor(config.Endpoint, config.RequestLimits),
+		observeQueueWait: config.ObserveQueueWait,
+
+		disableKeepAlives:   config.DisableKeepAlives,
+		disableQueryOptions: config.DisableQueryOptions,
+		cacheTTL:            config.CacheTTL,
+		observeCache:        config.ObserveCache,
+		observeConnection:   config.ObserveConnection,
+		observeTLSHandshake: config.ObserveTLSHandshake,
 	}
 
 	if config.TLSHandshakeTimeout == 0 {
 		config.TLSHandshakeTimeout = 10
 	}
 
+	if !config.DisableCache {
+		client.cache = cacheFor(config)
+	}
+
 	if config.HTTPClient == nil {
-		defaultTransport := http.DefaultTransport.(*http.Transport)
-		transport := &http.Transport{
-			Proxy:                 defaultTransport.Proxy,
-			DialContext:           defaultTransport.DialContext,
-			MaxIdleConns:          defaultTransport.MaxIdleConns,
-			IdleConnTimeout:       defaultTransport.IdleConnTimeout,
-			ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
-			TLSHandshakeTimeout:   time.Duration(config.TLSHandshakeTimeout) * time.Second,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: config.Insecure,
-			},
+		transport, err := transportFor(config)
+		if err != nil {
+			return nil, err
 		}
 		client.HTTPClient = &http.Client{Transport: transport}
 	} else {
@@ -133,15 +237,52 @@ func setupClientWithConfig(ctx context.Context, config *ClientConfig) (c *APICli
 	return client, nil
 }
 
+// newTLSConfig builds the TLS configuration of the transport from the client config
+func newTLSConfig(config *ClientConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.Insecure, // nolint:gosec
+		ServerName:         config.TLSServerName,
+		MinVersion:         config.TLSMinVersion,
+	}
+
+	if config.TLSCAFile != "" {
+		caPEM, err := os.ReadFile(config.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", config.TLSCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.TLSCertFile != "" || config.TLSKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.TLSCertFile, config.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
 // setupClientWithEndpoint setups the client using only the endpoint
 func setupClientWithEndpoint(ctx context.Context, endpoint string) (c *APIClient, err error) {
 	if !strings.HasPrefix(endpoint, "http") {
 		return c, fmt.Errorf("endpoint must starts with http or https")
 	}
 
+	endpoint, pathPrefix, err := splitEndpoint(endpoint)
+	if err != nil {
+		return c, err
+	}
+
 	client := &APIClient{
-		endpoint: endpoint,
-		ctx:      ctx,
+		endpoint:   endpoint,
+		pathPrefix: pathPrefix,
+		ctx:        ctx,
 	}
 	client.HTTPClient = &http.Client{}
 
@@ -154,35 +295,6 @@ func setupClientWithEndpoint(ctx context.Context, endpoint string) (c *APIClient
 	return client, nil
 }
 
-// setupClientAuth setups the authentication in the client using the client config
-func (c *APIClient) setupClientAuth(config *ClientConfig) error {
-	if config.Session != nil {
-		c.auth = &common.AuthToken{
-			Session: config.Session.ID,
-			Token:   config.Session.Token,
-		}
-	} else if config.Username != "" {
-		var auth *common.AuthToken
-		if config.BasicAuth {
-			auth = &common.AuthToken{
-				Username:  config.Username,
-				Password:  config.Password,
-				BasicAuth: true,
-			}
-		} else {
-			var err error
-			auth, err = c.Service.CreateSession(config.Username, config.Password)
-			if err != nil {
-				return err
-			}
-		}
-
-		c.auth = auth
-	}
-
-	return nil
-}
-
 // Connect creates a new client connection to a Redfish service.
 func Connect(config ClientConfig) (c *APIClient, err error) { // nolint:gocritic
 	return ConnectContext(context.Background(), config)
@@ -240,10 +352,25 @@ func (c *APIClient) CloneWithSession() (*APIClient, error) {
 		return nil, err
 	}
 	newClient.auth = auth
+	newClient.authMode = AuthModeSession
 
 	return &newClient, err
 }
 
+// QueryOptions returns the query parameters the service announces support
+// for in its service root, see common.ListCollection.
+func (c *APIClient) QueryOptions() common.QueryOptions {
+	if c.Service == nil || c.disableQueryOptions {
+		return common.QueryOptions{}
+	}
+	features := c.Service.ProtocolFeaturesSupported
+	return common.QueryOptions{
+		Expand:       features.ExpandQuery.NoLinks,
+		ExpandLevels: features.ExpandQuery.NoLinks && features.ExpandQuery.Levels,
+		Select:       features.SelectQuery,
+	}
+}
+
 // GetSession retrieves the session data from an initialized APIClient. An error
 // is returned if the client is not authenticated.
 func (c *APIClient) GetSession() (*Session, error) {
@@ -258,6 +385,9 @@ func (c *APIClient) GetSession() (*Session, error) {
 
 // Get performs a GET request against the Redfish service.
 func (c *APIClient) Get(url string) (*http.Response, error) {
+	if c.cache != nil {
+		return c.cachedGet(url)
+	}
 	return c.GetWithHeaders(url, nil)
 }
 
@@ -388,8 +518,7 @@ func (c *APIClient) runRawRequestWithHeaders(method, url string, payloadBuffer i
 		return nil, common.ConstructError(0, []byte("unable to execute request, no target provided"))
 	}
 
-	endpoint := fmt.Sprintf("%s%s", c.endpoint, url)
-	req, err := http.NewRequestWithContext(c.ctx, method, endpoint, payloadBuffer)
+	req, err := http.NewRequestWithContext(c.ctx, method, c.resolve(url), payloadBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -420,7 +549,11 @@ func (c *APIClient) runRawRequestWithHeaders(method, url string, payloadBuffer i
 			req.Header.Set("Authorization", fmt.Sprintf("Basic %v", encodedAuth))
 		}
 	}
-	req.Close = true
+	req.Close = c.disableKeepAlives
+
+	if trace := c.clientTrace(); trace != nil {
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+	}
 
 	// Dump request if needed.
 	if c.dumpWriter != nil {
@@ -429,17 +562,31 @@ func (c *APIClient) runRawRequestWithHeaders(method, url string, payloadBuffer i
 			return nil, common.ConstructError(0, []byte(err.Error()))
 		}
 
-		d = append(d, '\n')
+		d = append(redactDump(d), '\n')
 		_, err = c.dumpWriter.Write(d)
 		if err != nil {
 			panic(err)
 		}
 	}
 
+	release := func() {}
+	if c.limiter != nil {
+		start := time.Now()
+		release, err = c.limiter.acquire(c.ctx)
+		if err != nil {
+			return nil, err
+		}
+		if c.observeQueueWait != nil {
+			c.observeQueueWait(time.Since(start))
+		}
+	}
+
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
+		release()
 		return nil, err
 	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
 
 	// Dump response if needed.
 	if c.dumpWriter != nil {
@@ -449,7 +596,7 @@ func (c *APIClient) runRawRequestWithHeaders(method, url string, payloadBuffer i
 			return nil, common.ConstructError(0, []byte(err.Error()))
 		}
 
-		d = append(d, '\n')
+		d = append(redactDump(d), '\n')
 		_, err = c.dumpWriter.Write(d)
 		if err != nil {
 			panic(err)
@@ -7,8 +7,12 @@ package common
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
 )
 
+// CollectionWorkers is the number of members FetchCollection fetches in parallel.
+var CollectionWorkers = 4
+
 // Collection represents a collection of entity references.
 type Collection struct {
 	Name      string `json:"Name"`
@@ -58,6 +62,55 @@ func GetCollection(c Client, uri string) (*Collection, error) {
 	return &result, nil
 }
 
+// FetchCollection calls fetch for every link, at most CollectionWorkers at a
+// time, and returns the members fetched in the order of links. Failures are
+// returned as a *CollectionError along with the members that were fetched.
+func FetchCollection(links []string, fetch func(link string) (interface{}, error)) ([]interface{}, error) {
+	members := make([]interface{}, len(links))
+	errs := make([]error, len(links))
+
+	workers := CollectionWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(links) {
+		workers = len(links)
+	}
+
+	indexes := make(chan int)
+	wg := &sync.WaitGroup{}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				members[i], errs[i] = fetch(links[i])
+			}
+		}()
+	}
+	for i := range links {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var result []interface{}
+	collectionError := NewCollectionError()
+	for i, link := range links {
+		if errs[i] != nil {
+			collectionError.Failures[link] = errs[i]
+		} else {
+			result = append(result, members[i])
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
 // CollectionError is used for collecting errors when working with collections
 type CollectionError struct {
 	Failures map[string]error
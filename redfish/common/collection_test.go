@@ -8,7 +8,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 var collectionBody = strings.NewReader(
@@ -55,3 +57,53 @@ func TestCollection(t *testing.T) {
 		}
 	}
 }
+
+func TestFetchCollection(t *testing.T) {
+	var links []string
+	for i := 0; i < 20; i++ {
+		links = append(links, fmt.Sprintf("/redfish/v1/Chassis/1/Drives/%d", i))
+	}
+
+	var inFlight, maxInFlight int32
+	members, err := FetchCollection(links, func(link string) (interface{}, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		if strings.HasSuffix(link, "/7") {
+			return nil, fmt.Errorf("drive missing")
+		}
+		return link, nil
+	})
+
+	collectionError, ok := err.(*CollectionError)
+	if !ok || len(collectionError.Failures) != 1 || collectionError.Failures[links[7]] == nil {
+		t.Errorf("expected a CollectionError for %s, got %v", links[7], err)
+	}
+	if len(members) != 19 {
+		t.Fatalf("got %d members, want 19", len(members))
+	}
+	for i, member := range members {
+		want := links[i]
+		if i >= 7 {
+			want = links[i+1]
+		}
+		if member.(string) != want {
+			t.Errorf("member %d is %s, want %s", i, member, want)
+		}
+	}
+	if maxInFlight > int32(CollectionWorkers) {
+		t.Errorf("got %d fetches in flight, want at most %d", maxInFlight, CollectionWorkers)
+	}
+
+	members, err = FetchCollection(nil, nil)
+	if err != nil || len(members) != 0 {
+		t.Errorf("empty collection returned %v, %v", members, err)
+	}
+}
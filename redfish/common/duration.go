@@ -0,0 +1,38 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var durationRegexp = regexp.MustCompile(`^P(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseDuration parses the ISO 8601 durations used by Redfish, such as
+// "P1DT2H3M4.5S". Years, months and weeks are not supported.
+func ParseDuration(value string) (time.Duration, error) {
+	matches := durationRegexp.FindStringSubmatch(value)
+	if matches == nil || value == "P" || value == "PT" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var result time.Duration
+	for i, unit := range units {
+		if matches[i+1] == "" {
+			continue
+		}
+		amount, err := strconv.ParseFloat(matches[i+1], 64)
+		if err != nil {
+			return 0, err
+		}
+		result += time.Duration(amount * float64(unit))
+	}
+
+	return result, nil
+}
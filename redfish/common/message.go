@@ -61,24 +61,12 @@ func ListReferencedMessages(c Client, link string) ([]*Message, error) {
 		return result, nil
 	}
 
-	links, err := GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := NewCollectionError()
-	for _, messageLink := range links.ItemLinks {
-		message, err := GetMessage(c, messageLink)
-		if err != nil {
-			collectionError.Failures[messageLink] = err
-		} else {
-			result = append(result, message)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := ListCollection(c, link, nil, func(c Client, messageLink string) (interface{}, error) {
+		return GetMessage(c, messageLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Message))
 	}
 
-	return result, collectionError
+	return result, err
 }
@@ -0,0 +1,166 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// QueryOptions are the query parameters a service supports, as announced in
+// the ProtocolFeaturesSupported of its service root.
+type QueryOptions struct {
+	// Expand is set if the service expands subordinate resources with $expand=.
+	Expand bool
+	// ExpandLevels is set if the service supports $levels within $expand.
+	ExpandLevels bool
+	// Select is set if the service supports $select.
+	Select bool
+}
+
+// QueryOptionsClient is implemented by the clients which know the query
+// parameters of their service. Other clients don't use any.
+type QueryOptionsClient interface {
+	QueryOptions() QueryOptions
+}
+
+// ListCollection returns the members of the collection at uri in order,
+// fetching each with fetch. If the service supports $expand the members are
+// read along with the collection, limited to the properties in selectProps if
+// it supports $select, and fetch reads them from memory. The members the
+// expanded collection lacks, or all of them if it can't be read, are fetched
+// from the service.
+func ListCollection(c Client, uri string, selectProps []string, fetch func(c Client, link string) (interface{}, error)) ([]interface{}, error) {
+	if expanded, ok := getExpandedCollection(c, uri, selectProps); ok {
+		return FetchCollection(expanded.links, func(link string) (interface{}, error) {
+			return fetch(expanded, link)
+		})
+	}
+
+	links, err := GetCollection(c, uri)
+	if err != nil {
+		return nil, err
+	}
+	return FetchCollection(links.ItemLinks, func(link string) (interface{}, error) {
+		return fetch(c, link)
+	})
+}
+
+// ListExpandedCollection is ListCollection for collections which are too
+// costly to read member by member. It only returns the members the service
+// expands, and reports false without reading any if it can't expand the
+// collection.
+func ListExpandedCollection(c Client, uri string, selectProps []string, fetch func(c Client, link string) (interface{}, error)) ([]interface{}, bool, error) {
+	expanded, ok := getExpandedCollection(c, uri, selectProps)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var links []string
+	for _, link := range expanded.links {
+		if _, ok := expanded.members[memberKey(link)]; ok {
+			links = append(links, link)
+		}
+	}
+	members, err := FetchCollection(links, func(link string) (interface{}, error) {
+		return fetch(expanded, link)
+	})
+	return members, true, err
+}
+
+// expandedCollection serves the members of an expanded collection and
+// passes every other request to the client.
+type expandedCollection struct {
+	Client
+	links   []string
+	members map[string][]byte
+}
+
+// getExpandedCollection reads the collection at uri with its members
+// expanded, if the service supports it.
+func getExpandedCollection(c Client, uri string, selectProps []string) (*expandedCollection, bool) {
+	qc, ok := c.(QueryOptionsClient)
+	if !ok {
+		return nil, false
+	}
+	options := qc.QueryOptions()
+	if !options.Expand {
+		return nil, false
+	}
+
+	query := "$expand=."
+	if options.ExpandLevels {
+		query = "$expand=.($levels=1)"
+	}
+	if options.Select && len(selectProps) > 0 {
+		query += "&$select=" + strings.Join(selectProps, ",")
+	}
+	separator := "?"
+	if strings.Contains(uri, "?") {
+		separator = "&"
+	}
+
+	resp, err := c.Get(uri + separator + query)
+	if err != nil {
+		return nil, false
+	}
+	defer resp.Body.Close()
+
+	var collection struct {
+		Members []json.RawMessage
+		Count   int `json:"Members@odata.count"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&collection); err != nil {
+		return nil, false
+	}
+	if collection.Count > len(collection.Members) {
+		return nil, false
+	}
+
+	expanded := &expandedCollection{
+		Client:  c,
+		members: make(map[string][]byte),
+	}
+	for _, raw := range collection.Members {
+		var member map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &member); err != nil {
+			return nil, false
+		}
+		var link string
+		if err := json.Unmarshal(member["@odata.id"], &link); err != nil || link == "" {
+			return nil, false
+		}
+		expanded.links = append(expanded.links, link)
+		// members which were not expanded are fetched
+		if len(member) > 1 {
+			expanded.members[memberKey(link)] = raw
+		}
+	}
+
+	return expanded, true
+}
+
+func memberKey(link string) string {
+	return strings.TrimSuffix(link, "/")
+}
+
+// Get returns the expanded member at url, or gets it from the service.
+func (e *expandedCollection) Get(url string) (*http.Response, error) {
+	if raw, ok := e.members[memberKey(url)]; ok {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(bytes.NewReader(raw)),
+		}, nil
+	}
+	return e.Client.Get(url)
+}
+
+// QueryOptions passes the query options of the client on to the members.
+func (e *expandedCollection) QueryOptions() QueryOptions {
+	if qc, ok := e.Client.(QueryOptionsClient); ok {
+		return qc.QueryOptions()
+	}
+	return QueryOptions{}
+}
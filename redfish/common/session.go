@@ -130,24 +130,12 @@ func GetSession(c Client, uri string) (*Session, error) {
 // ListReferencedSessions gets the collection of Sessions
 func ListReferencedSessions(c Client, link string) ([]*Session, error) {
 	var result []*Session
-	links, err := GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := NewCollectionError()
-	for _, sLink := range links.ItemLinks {
-		s, err := GetSession(c, sLink)
-		if err != nil {
-			collectionError.Failures[sLink] = err
-		} else {
-			result = append(result, s)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := ListCollection(c, link, nil, func(c Client, sLink string) (interface{}, error) {
+		return GetSession(c, sLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Session))
 	}
 
-	return result, collectionError
+	return result, err
 }
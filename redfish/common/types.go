@@ -442,8 +442,9 @@ type Placement struct {
 	// Rack shall be the name of the rack within a row.
 	Rack string
 	// RackOffset is the vertical location of the item in the rack. Rack offset
-	// units shall be measured from bottom to top starting with 0.
-	RackOffset int
+	// units shall be measured from bottom to top starting with 0. It is nil if
+	// the service does not report it.
+	RackOffset *int
 	// RackOffsetUnits shall be a RackUnit enumeration literal indicating the
 	// type of rack units in use.
 	RackOffsetUnits RackUnits
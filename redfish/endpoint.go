@@ -0,0 +1,100 @@
+package redfish
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// TargetEndpoint returns the endpoint of the Redfish service addressed by
+// target, which is a host name, an IPv4 or IPv6 address (bracketed or not),
+// a host:port or a full URL whose path is the prefix of a reverse proxy in
+// front of the service. defaultScheme and defaultPort are used when target
+// doesn't set them; a defaultPort of 0 leaves the port of the scheme.
+func TargetEndpoint(target, defaultScheme string, defaultPort int) (string, error) {
+	if defaultScheme == "" {
+		defaultScheme = "https"
+	}
+
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return "", fmt.Errorf("empty target")
+	}
+
+	raw := target
+	if !strings.Contains(target, "://") {
+		// a bare IPv6 address, possibly with a zone
+		if ip := net.ParseIP(strings.SplitN(target, "%", 2)[0]); ip != nil && strings.Contains(target, ":") {
+			raw = "[" + target + "]"
+		}
+		raw = defaultScheme + "://" + strings.Replace(raw, "%", "%25", 1)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid target %s: %s", target, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid target %s: scheme must be http or https", target)
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("invalid target %s: missing host", target)
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid target %s: credentials, query and fragment are not allowed", target)
+	}
+
+	port := u.Port()
+	if port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return "", fmt.Errorf("invalid target %s: invalid port %s", target, port)
+		}
+	} else if defaultPort != 0 {
+		port = strconv.Itoa(defaultPort)
+	}
+
+	host := u.Hostname()
+	if strings.Contains(host, ":") {
+		host = "[" + strings.Replace(host, "%", "%25", 1) + "]"
+	}
+	if port != "" {
+		host = host + ":" + port
+	}
+
+	// the client appends the service root itself
+	prefix := strings.TrimRight(u.EscapedPath(), "/")
+	prefix = strings.TrimSuffix(prefix, strings.TrimRight(common.DefaultServiceRoot, "/"))
+
+	return u.Scheme + "://" + host + prefix, nil
+}
+
+// splitEndpoint splits an endpoint into its scheme://host[:port] part and
+// its path prefix.
+func splitEndpoint(endpoint string) (base, pathPrefix string, err error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", "", err
+	}
+	return u.Scheme + "://" + u.Host, strings.TrimRight(u.EscapedPath(), "/"), nil
+}
+
+// resolve returns the URL of the resource at uri, which is usually an
+// @odata.id. The path prefix is added to absolute paths which don't have it
+// yet, so links of services which don't know about their reverse proxy are
+// followed through it.
+func (c *APIClient) resolve(uri string) string {
+	if strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://") {
+		return uri
+	}
+	if !strings.HasPrefix(uri, "/") {
+		uri = "/" + uri
+	}
+	if c.pathPrefix != "" && uri != c.pathPrefix && !strings.HasPrefix(uri, c.pathPrefix+"/") {
+		uri = c.pathPrefix + uri
+	}
+	return c.endpoint + uri
+}
@@ -0,0 +1,199 @@
+package redfish
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/redfishapi"
+)
+
+const (
+	eventStreamMinBackoff = time.Second
+	eventStreamMaxBackoff = 5 * time.Minute
+	// maxEventSize is the largest event the stream accepts.
+	maxEventSize = 1024 * 1024
+)
+
+// EventStream keeps a server-sent event stream to the event service of a
+// Redfish service open, reconnecting and resuming from the last received
+// event when the stream breaks.
+type EventStream struct {
+	config  ClientConfig
+	handler func(event *redfishapi.Event)
+
+	// OnError is called with the error that ended a connection attempt or
+	// the stream, before reconnecting.
+	OnError func(err error)
+
+	lastEventID string
+	retry       time.Duration
+}
+
+// NewEventStream returns an EventStream connecting with config and calling
+// handler for every event received.
+func NewEventStream(config ClientConfig, handler func(event *redfishapi.Event)) *EventStream { // nolint:gocritic
+	// dumping the response would read the stream until it is closed
+	config.DumpWriter = nil
+	// the stream would hold an in-flight slot for as long as it is open
+	config.RequestLimits = RequestLimits{}
+
+	return &EventStream{
+		config:  config,
+		handler: handler,
+	}
+}
+
+// Run reads the event stream until ctx is done.
+func (s *EventStream) Run(ctx context.Context) {
+	backoff := eventStreamMinBackoff
+	for {
+		connected, err := s.stream(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = fmt.Errorf("event stream closed by the service")
+		}
+		if s.OnError != nil {
+			s.OnError(err)
+		}
+
+		if connected {
+			backoff = eventStreamMinBackoff
+		}
+		wait := backoff
+		if s.retry > wait {
+			wait = s.retry
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		backoff *= 2
+		if backoff > eventStreamMaxBackoff {
+			backoff = eventStreamMaxBackoff
+		}
+	}
+}
+
+// stream connects to the event stream and reads it until it breaks. It
+// reports whether the stream was opened.
+func (s *EventStream) stream(ctx context.Context) (bool, error) {
+	c, err := ConnectContext(ctx, s.config)
+	if err != nil {
+		return false, err
+	}
+	defer c.Logout()
+
+	eventService, err := c.Service.EventService()
+	if err != nil {
+		return false, err
+	}
+	if eventService == nil || eventService.ServerSentEventURI == "" {
+		return false, fmt.Errorf("service does not support server-sent events")
+	}
+
+	headers := map[string]string{
+		"Accept":        "text/event-stream",
+		"Cache-Control": "no-cache",
+		"Last-Event-ID": s.lastEventID,
+	}
+	resp, err := c.GetWithHeaders(eventService.ServerSentEventURI, headers)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	return true, readEventStream(resp.Body, s.dispatch)
+}
+
+// dispatch handles a single message of the stream.
+func (s *EventStream) dispatch(msg *eventStreamMessage) {
+	if msg.id != "" {
+		s.lastEventID = msg.id
+	}
+	if msg.retry > 0 {
+		s.retry = msg.retry
+	}
+	if len(msg.data) == 0 {
+		return
+	}
+
+	var event redfishapi.Event
+	if err := json.Unmarshal(msg.data, &event); err != nil {
+		if s.OnError != nil {
+			s.OnError(fmt.Errorf("error decoding event %q: %w", msg.id, err))
+		}
+		return
+	}
+	// metric reports share the stream with the events
+	if len(event.Events) == 0 {
+		return
+	}
+	s.handler(&event)
+}
+
+// eventStreamMessage is a message of a text/event-stream.
+type eventStreamMessage struct {
+	id    string
+	event string
+	data  []byte
+	retry time.Duration
+}
+
+// readEventStream parses the text/event-stream read from r and calls
+// dispatch for every message until r is exhausted.
+func readEventStream(r io.Reader, dispatch func(msg *eventStreamMessage)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 4096), maxEventSize)
+
+	msg := &eventStreamMessage{}
+	var data bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if data.Len() > 0 || msg.id != "" || msg.retry > 0 {
+				msg.data = bytes.TrimSuffix(data.Bytes(), []byte("\n"))
+				dispatch(msg)
+			}
+			msg = &eventStreamMessage{}
+			data = bytes.Buffer{}
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			// comment, used as keep-alive
+			continue
+		}
+
+		field, value := line, ""
+		if i := strings.Index(line, ":"); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+
+		switch field {
+		case "id":
+			msg.id = value
+		case "event":
+			msg.event = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil {
+				msg.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,50 @@
+package redfish
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadEventStream(t *testing.T) {
+	stream := strings.Join([]string{
+		": keep-alive",
+		"retry: 5000",
+		"",
+		"id: 1",
+		`data: {"Events": [{"MessageId": "Base.1.0.Success",`,
+		`data: "MessageSeverity": "OK"}]}`,
+		"",
+		"id: 2",
+		"event: Event",
+		"data:{}",
+		"",
+		"id: 3",
+		"data: partial",
+	}, "\n")
+
+	var msgs []*eventStreamMessage
+	err := readEventStream(strings.NewReader(stream), func(msg *eventStreamMessage) {
+		msgs = append(msgs, msg)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(msgs) != 3 {
+		t.Fatalf("got %d messages, want 3", len(msgs))
+	}
+	if msgs[0].retry != 5*time.Second {
+		t.Errorf("retry = %v, want 5s", msgs[0].retry)
+	}
+	if msgs[1].id != "1" {
+		t.Errorf("id = %q, want 1", msgs[1].id)
+	}
+	want := "{\"Events\": [{\"MessageId\": \"Base.1.0.Success\",\n\"MessageSeverity\": \"OK\"}]}"
+	if string(msgs[1].data) != want {
+		t.Errorf("data = %q, want %q", msgs[1].data, want)
+	}
+	if msgs[2].id != "2" || msgs[2].event != "Event" || string(msgs[2].data) != "{}" {
+		t.Errorf("unexpected message %+v", msgs[2])
+	}
+}
@@ -0,0 +1,141 @@
+package redfish
+
+import (
+	"context"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// RequestLimits limits the requests sent to a service by all the clients
+// connected to the same endpoint. Zero values are unlimited.
+type RequestLimits struct {
+	// MaxInFlight is the maximum number of requests waiting for or reading
+	// a response.
+	MaxInFlight int
+	// RequestsPerSecond is the rate the token bucket is refilled at.
+	RequestsPerSecond float64
+	// Burst is the size of the token bucket, default 1.
+	Burst int
+}
+
+// requestLimiter enforces RequestLimits for one endpoint.
+type requestLimiter struct {
+	limits   RequestLimits
+	inFlight chan struct{}
+
+	mutex  sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// requestLimiters holds the requestLimiter of every endpoint.
+var requestLimiters sync.Map
+
+// limiterFor returns the requestLimiter of endpoint, replacing it if its
+// limits changed, or nil if limits are unlimited.
+func limiterFor(endpoint string, limits RequestLimits) *requestLimiter {
+	if limits.MaxInFlight <= 0 && limits.RequestsPerSecond <= 0 {
+		return nil
+	}
+	if l, ok := requestLimiters.Load(endpoint); ok && l.(*requestLimiter).limits == limits {
+		return l.(*requestLimiter)
+	}
+
+	l := &requestLimiter{
+		limits: limits,
+		tokens: math.Max(float64(limits.Burst), 1),
+		last:   time.Now(),
+	}
+	if limits.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, limits.MaxInFlight)
+	}
+	requestLimiters.Store(endpoint, l)
+	return l
+}
+
+// acquire waits until a request may be sent and returns the function
+// releasing its in-flight slot.
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	if l.limits.RequestsPerSecond > 0 {
+		if err := sleepContext(ctx, l.reserve()); err != nil {
+			// the request is not sent, its token is left for the next one
+			l.unreserve()
+			return nil, err
+		}
+	}
+
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-l.inFlight })
+	}, nil
+}
+
+// reserve takes a token from the bucket and returns how long to wait for it.
+func (l *requestLimiter) reserve() time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	burst := math.Max(float64(l.limits.Burst), 1)
+	now := time.Now()
+	l.tokens = math.Min(burst, l.tokens+now.Sub(l.last).Seconds()*l.limits.RequestsPerSecond)
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.limits.RequestsPerSecond * float64(time.Second))
+}
+
+// unreserve returns a token taken by reserve for a request which was not sent.
+func (l *requestLimiter) unreserve() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	burst := math.Max(float64(l.limits.Burst), 1)
+	l.tokens = math.Min(burst, l.tokens+1)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// releasingBody releases the in-flight slot of a request once its response
+// body is read or closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.release()
+	}
+	return n, err
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
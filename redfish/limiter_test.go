@@ -0,0 +1,120 @@
+package redfish
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestLimitsMaxInFlight(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var waits int32
+	config := ClientConfig{
+		Endpoint:         server.URL,
+		RequestLimits:    RequestLimits{MaxInFlight: 2},
+		ObserveQueueWait: func(time.Duration) { atomic.AddInt32(&waits, 1) },
+	}
+	// every scrape connects its own client, the limit is shared
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		c, err := Connect(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(2)
+		for j := 0; j < 2; j++ {
+			go func() {
+				defer wg.Done()
+				resp, err := c.Get("/redfish/v1/Systems")
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				_, _ = io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}()
+		}
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("got %d requests in flight, want at most 2", maxInFlight)
+	}
+	// the service root of each connect and the 8 gets
+	if waits != 12 {
+		t.Errorf("observed %d queue waits, want 12", waits)
+	}
+}
+
+func TestRequestLimitsRate(t *testing.T) {
+	l := limiterFor("https://rate.example.com", RequestLimits{RequestsPerSecond: 50, Burst: 2})
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		release, err := l.acquire(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+	// the burst passes at once, the other 3 wait 20ms each
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > time.Second {
+		t.Errorf("5 requests took %s, want about 60ms", elapsed)
+	}
+
+	if limiterFor("https://rate.example.com", RequestLimits{RequestsPerSecond: 50, Burst: 2}) != l {
+		t.Error("limiter was not shared")
+	}
+	if limiterFor("https://rate.example.com", RequestLimits{RequestsPerSecond: 10}) == l {
+		t.Error("limiter was not replaced after its limits changed")
+	}
+}
+
+func TestRequestLimitsCancel(t *testing.T) {
+	l := limiterFor("https://cancel.example.com", RequestLimits{RequestsPerSecond: 1, Burst: 1})
+
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+
+	// requests cancelled while waiting for a token give it back
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		if _, err := l.acquire(ctx); err == nil {
+			t.Fatal("expected the request to be cancelled while waiting")
+		}
+		cancel()
+	}
+
+	// the next request waits for the one token, not the cancelled ones too
+	start := time.Now()
+	release, err = l.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	if elapsed := time.Since(start); elapsed > 1500*time.Millisecond {
+		t.Errorf("request after cancelled requests waited %s, want at most 1s", elapsed)
+	}
+}
@@ -0,0 +1,128 @@
+package redfish
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+	"github.com/magicst0ne/rackserver_exporter/redfish/redfishapi"
+)
+
+// newExpandTestServer serves a memory collection of 3 DIMMs, expanding it if
+// expand is set and failing the expanded request if brokenExpand is set.
+func newExpandTestServer(expand, brokenExpand bool, requests *[]string) *httptest.Server {
+	mutex := &sync.Mutex{}
+	memory := func(i int) string {
+		return fmt.Sprintf(`{"@odata.id": "/redfish/v1/Systems/1/Memory/%d", "Id": "%d", "DeviceLocator": "DIMM %d", "CapacityMiB": 32768}`, i, i, i)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		*requests = append(*requests, r.URL.RequestURI())
+		mutex.Unlock()
+
+		switch {
+		case r.URL.Path == "/redfish/v1/":
+			fmt.Fprintf(w, `{"ProtocolFeaturesSupported": {"ExpandQuery": {"NoLinks": %t, "Levels": %t}, "SelectQuery": %t}}`, expand, expand, expand)
+		case r.URL.Path == "/redfish/v1/Systems/1/Memory" && r.URL.Query().Get("$expand") != "":
+			if brokenExpand {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			fmt.Fprintf(w, `{"Members@odata.count": 3, "Members": [%s, %s, %s]}`, memory(0), memory(1), memory(2))
+		case r.URL.Path == "/redfish/v1/Systems/1/Memory":
+			fmt.Fprint(w, `{"Members@odata.count": 3, "Members": [{"@odata.id": "/redfish/v1/Systems/1/Memory/0"}, {"@odata.id": "/redfish/v1/Systems/1/Memory/1"}, {"@odata.id": "/redfish/v1/Systems/1/Memory/2"}]}`)
+		case strings.HasPrefix(r.URL.Path, "/redfish/v1/Systems/1/Memory/"):
+			var i int
+			fmt.Sscanf(r.URL.Path, "/redfish/v1/Systems/1/Memory/%d", &i)
+			fmt.Fprint(w, memory(i))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestListCollectionExpand(t *testing.T) {
+	tests := []struct {
+		name                 string
+		expand, brokenExpand bool
+		// requests after the service root
+		requests int
+	}{
+		{"expanded", true, false, 1},
+		{"not supported", false, false, 4},
+		{"expand fails", true, true, 5},
+	}
+	for _, test := range tests {
+		var requests []string
+		server := newExpandTestServer(test.expand, test.brokenExpand, &requests)
+
+		c, err := Connect(ClientConfig{Endpoint: server.URL})
+		if err != nil {
+			t.Fatal(err)
+		}
+		memories, err := redfishapi.ListReferencedMemorys(c, "/redfish/v1/Systems/1/Memory")
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+		if len(memories) != 3 {
+			t.Fatalf("%s: got %d memories, want 3", test.name, len(memories))
+		}
+		for i, memory := range memories {
+			if memory.DeviceLocator != fmt.Sprintf("DIMM %d", i) || memory.CapacityMiB != 32768 {
+				t.Errorf("%s: memory %d decoded as %+v", test.name, i, memory)
+			}
+		}
+
+		if len(requests)-1 != test.requests {
+			t.Errorf("%s: got requests %v, want %d after the service root", test.name, requests, test.requests)
+		}
+		if test.expand && !strings.Contains(requests[1], "$expand=.($levels=1)&$select=Id,Name,DeviceLocator,Status,CapacityMiB,Metrics") {
+			t.Errorf("%s: expanded request is %s", test.name, requests[1])
+		}
+
+		server.Close()
+	}
+}
+
+func TestListExpandedCollection(t *testing.T) {
+	tests := []struct {
+		name                 string
+		expand, brokenExpand bool
+		ok                   bool
+		members              int
+	}{
+		{"expanded", true, false, true, 3},
+		{"not supported", false, false, false, 0},
+		{"expand fails", true, true, false, 0},
+	}
+	for _, test := range tests {
+		var requests []string
+		server := newExpandTestServer(test.expand, test.brokenExpand, &requests)
+
+		c, err := Connect(ClientConfig{Endpoint: server.URL})
+		if err != nil {
+			t.Fatal(err)
+		}
+		members, ok, err := common.ListExpandedCollection(c, "/redfish/v1/Systems/1/Memory", nil, func(c common.Client, link string) (interface{}, error) {
+			return redfishapi.GetMemory(c, link)
+		})
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+		if ok != test.ok || len(members) != test.members {
+			t.Errorf("%s: got %d members and %t, want %d and %t", test.name, len(members), ok, test.members, test.ok)
+		}
+		// the members are never read one by one
+		for _, request := range requests {
+			if strings.HasPrefix(request, "/redfish/v1/Systems/1/Memory/") {
+				t.Errorf("%s: member read with %s", test.name, request)
+			}
+		}
+
+		server.Close()
+	}
+}
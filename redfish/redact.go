@@ -0,0 +1,22 @@
+package redfish
+
+import (
+	"regexp"
+)
+
+const redacted = "<redacted>"
+
+var (
+	// secretHeaders matches the headers carrying credentials or session tokens.
+	secretHeaders = regexp.MustCompile(`(?mi)^(Authorization|Proxy-Authorization|X-Auth-Token|Cookie|Set-Cookie):[^\r\n]*`)
+	// secretProperties matches the JSON properties carrying credentials, such
+	// as the Password of a session creation request.
+	secretProperties = regexp.MustCompile(`(?i)("[^"]*(?:password|passphrase|secret|token)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+// redactDump replaces the credentials and session tokens of an HTTP dump
+// before it is handed to the DumpWriter.
+func redactDump(dump []byte) []byte {
+	dump = secretHeaders.ReplaceAll(dump, []byte("${1}: "+redacted))
+	return secretProperties.ReplaceAll(dump, []byte(`${1}"`+redacted+`"`))
+}
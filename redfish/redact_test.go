@@ -0,0 +1,32 @@
+package redfish
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactDump(t *testing.T) {
+	dump := strings.Join([]string{
+		"POST /redfish/v1/SessionService/Sessions HTTP/1.1",
+		"Host: 10.0.0.1",
+		"Authorization: Basic cm9vdDpjYWx2aW4=",
+		"X-Auth-Token: 4f8e2a",
+		"Cookie: sessionKey=4f8e2a",
+		"Content-Type: application/json",
+		"",
+		`{"UserName": "root", "Password": "cal\"vin"}`,
+	}, "\r\n")
+
+	got := string(redactDump([]byte(dump)))
+
+	for _, secret := range []string{"cm9vdDpjYWx2aW4=", "4f8e2a", "cal"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("dump still contains %q:\n%s", secret, got)
+		}
+	}
+	for _, kept := range []string{"Host: 10.0.0.1", "Content-Type: application/json", `"UserName": "root"`, `"Password": "<redacted>"`, "X-Auth-Token: <redacted>\r\n"} {
+		if !strings.Contains(got, kept) {
+			t.Errorf("dump lost %q:\n%s", kept, got)
+		}
+	}
+}
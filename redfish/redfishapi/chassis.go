@@ -157,9 +157,16 @@ type Chassis struct {
 	ChassisType ChassisType
 	// Description provides a description of this resource.
 	Description string
+	// IndicatorLED shall contain the indicator light state for the indicator
+	// light associated with this chassis. It is deprecated in favor of
+	// LocationIndicatorActive.
+	IndicatorLED common.IndicatorLED
 	// Location shall contain location information of the
 	// associated chassis.
 	Location common.Location
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive *bool
 	// Manufacturer shall contain the name of the
 	// organization responsible for producing the chassis. This organization
 	// might be the entity from whom the chassis is purchased, but this is
@@ -172,6 +179,9 @@ type Chassis struct {
 	// the organization that is responsible for producing or manufacturing
 	// the chassis.
 	PartNumber string
+	// PhysicalSecurity shall contain the sensor state of the physical
+	// security.
+	PhysicalSecurity PhysicalSecurity
 	// PowerState shall contain the power state of the
 	// chassis.
 	PowerState string
@@ -187,6 +197,36 @@ type Chassis struct {
 	UUID string
 	thermal         string
 	power           string
+	// networkAdapters shall be a link to a collection of type
+	// NetworkAdapterCollection.
+	networkAdapters string
+	// pcieDevices shall be a link to a collection of type PCIeDeviceCollection.
+	pcieDevices string
+	// pcieDeviceLinks shall be the links to the PCIe devices of older services
+	// which list them under Links.
+	pcieDeviceLinks []string
+	// pcieSlots shall be a link to a resource of type PCIeSlots.
+	pcieSlots string
+	// thermalSubsystem shall be a link to a resource of type
+	// ThermalSubsystem, which replaces Thermal.
+	thermalSubsystem string
+	// powerSubsystem shall be a link to a resource of type PowerSubsystem,
+	// which replaces Power.
+	powerSubsystem string
+	// environmentMetrics shall be a link to a resource of type
+	// EnvironmentMetrics.
+	environmentMetrics string
+	// sensors shall be a link to a collection of type SensorCollection.
+	sensors string
+	// computerSystems shall be the links to the computer systems contained
+	// in this chassis.
+	computerSystems []string
+	// contains shall be the links to the chassis contained in this chassis.
+	contains []string
+	// containedBy shall be a link to the chassis containing this chassis.
+	containedBy string
+	// managedBy shall be the links to the managers of this chassis.
+	managedBy []string
 	rawData []byte
 }
 
@@ -194,13 +234,25 @@ type Chassis struct {
 func (chassis *Chassis) UnmarshalJSON(b []byte) error {
 	type temp Chassis
 	type linkReference struct {
+		ComputerSystems common.Links
+		Contains        common.Links
+		ContainedBy     common.Link
+		ManagedBy       common.Links
+		PCIeDevices     common.Links
 	}
 
 	var t struct {
 		temp
-		Thermal         common.Link
-		Power           common.Link
-		Links           linkReference
+		Thermal            common.Link
+		Power              common.Link
+		NetworkAdapters    common.Link
+		PCIeDevices        common.Link
+		PCIeSlots          common.Link
+		ThermalSubsystem   common.Link
+		PowerSubsystem     common.Link
+		EnvironmentMetrics common.Link
+		Sensors            common.Link
+		Links              linkReference
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -214,6 +266,18 @@ func (chassis *Chassis) UnmarshalJSON(b []byte) error {
 
 	chassis.thermal = string(t.Thermal)
 	chassis.power = string(t.Power)
+	chassis.networkAdapters = string(t.NetworkAdapters)
+	chassis.pcieDevices = string(t.PCIeDevices)
+	chassis.pcieDeviceLinks = t.Links.PCIeDevices.ToStrings()
+	chassis.pcieSlots = string(t.PCIeSlots)
+	chassis.thermalSubsystem = string(t.ThermalSubsystem)
+	chassis.powerSubsystem = string(t.PowerSubsystem)
+	chassis.environmentMetrics = string(t.EnvironmentMetrics)
+	chassis.sensors = string(t.Sensors)
+	chassis.computerSystems = t.Links.ComputerSystems.ToStrings()
+	chassis.contains = t.Links.Contains.ToStrings()
+	chassis.containedBy = string(t.Links.ContainedBy)
+	chassis.managedBy = t.Links.ManagedBy.ToStrings()
 
 	// This is a read/write object, so we need to save the raw object data for later
 	chassis.rawData = b
@@ -260,29 +324,31 @@ func GetChassis(c common.Client, uri string) (*Chassis, error) {
 	return &chassis, nil
 }
 
-// ListReferencedChassis gets the collection of Chassis from a provided reference.
-func ListReferencedChassis(c common.Client, link string) ([]*Chassis, error) {
+// GetChassises gets the Chassis from a list of links.
+func GetChassises(c common.Client, links []string) ([]*Chassis, error) {
 	var result []*Chassis
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
 
-	collectionError := common.NewCollectionError()
-	for _, chassisLink := range links.ItemLinks {
-		chassis, err := GetChassis(c, chassisLink)
-		if err != nil {
-			collectionError.Failures[chassisLink] = err
-		} else {
-			result = append(result, chassis)
-		}
+	members, err := common.FetchCollection(links, func(chassisLink string) (interface{}, error) {
+		return GetChassis(c, chassisLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Chassis))
 	}
 
-	if collectionError.Empty() {
-		return result, nil
+	return result, err
+}
+
+// ListReferencedChassis gets the collection of Chassis from a provided reference.
+func ListReferencedChassis(c common.Client, link string) ([]*Chassis, error) {
+	var result []*Chassis
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, chassisLink string) (interface{}, error) {
+		return GetChassis(c, chassisLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Chassis))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // Thermal gets the thermal temperature and cooling information for the chassis
@@ -312,3 +378,113 @@ func (chassis *Chassis) Power() (*Power, error) {
 
 	return power, nil
 }
+
+// NetworkAdapters gets the network adapters installed in the chassis
+func (chassis *Chassis) NetworkAdapters() ([]*NetworkAdapter, error) {
+	return ListReferencedNetworkAdapters(chassis.Client, chassis.networkAdapters)
+}
+
+// PCIeDevices gets the PCIe devices installed in the chassis
+func (chassis *Chassis) PCIeDevices() ([]*PCIeDevice, error) {
+	if chassis.pcieDevices != "" {
+		return ListReferencedPCIeDevices(chassis.Client, chassis.pcieDevices)
+	}
+	return GetPCIeDevices(chassis.Client, chassis.pcieDeviceLinks)
+}
+
+// PCIeSlots gets the PCIe slots of the chassis
+func (chassis *Chassis) PCIeSlots() (*PCIeSlots, error) {
+	if chassis.pcieSlots == "" {
+		return nil, nil
+	}
+
+	return GetPCIeSlots(chassis.Client, chassis.pcieSlots)
+}
+
+// ThermalSubsystem gets the thermal subsystem of the chassis
+func (chassis *Chassis) ThermalSubsystem() (*ThermalSubsystem, error) {
+	if chassis.thermalSubsystem == "" {
+		return nil, nil
+	}
+
+	return GetThermalSubsystem(chassis.Client, chassis.thermalSubsystem)
+}
+
+// PowerSubsystem gets the power subsystem of the chassis
+func (chassis *Chassis) PowerSubsystem() (*PowerSubsystem, error) {
+	if chassis.powerSubsystem == "" {
+		return nil, nil
+	}
+
+	return GetPowerSubsystem(chassis.Client, chassis.powerSubsystem)
+}
+
+// EnvironmentMetrics gets the environment metrics of the chassis
+func (chassis *Chassis) EnvironmentMetrics() (*EnvironmentMetrics, error) {
+	if chassis.environmentMetrics == "" {
+		return nil, nil
+	}
+
+	return GetEnvironmentMetrics(chassis.Client, chassis.environmentMetrics)
+}
+
+// ExpandedSensors gets the sensors of the chassis if the service expands
+// the sensor collection, see ListExpandedSensors.
+func (chassis *Chassis) ExpandedSensors() ([]*Sensor, bool, error) {
+	return ListExpandedSensors(chassis.Client, chassis.sensors)
+}
+
+// LocatorLED returns the state of the indicator used to physically locate
+// the chassis, from IndicatorLED or LocationIndicatorActive of newer services.
+func (chassis *Chassis) LocatorLED() common.IndicatorLED {
+	if chassis.IndicatorLED != "" {
+		return chassis.IndicatorLED
+	}
+	if chassis.LocationIndicatorActive == nil {
+		return ""
+	}
+	if *chassis.LocationIndicatorActive {
+		return common.LitIndicatorLED
+	}
+	return common.OffIndicatorLED
+}
+
+// ComputerSystemLinks returns the links to the computer systems contained in
+// the chassis.
+func (chassis *Chassis) ComputerSystemLinks() []string {
+	return chassis.computerSystems
+}
+
+// ComputerSystems gets the computer systems contained in the chassis
+func (chassis *Chassis) ComputerSystems() ([]*ComputerSystem, error) {
+	var result []*ComputerSystem
+
+	members, err := common.FetchCollection(chassis.computerSystems, func(computerSystemLink string) (interface{}, error) {
+		return GetComputerSystem(chassis.Client, computerSystemLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*ComputerSystem))
+	}
+
+	return result, err
+}
+
+// Contains gets the chassis contained in the chassis, such as the nodes of
+// a multi-node enclosure.
+func (chassis *Chassis) Contains() ([]*Chassis, error) {
+	return GetChassises(chassis.Client, chassis.contains)
+}
+
+// ContainedBy gets the chassis containing the chassis
+func (chassis *Chassis) ContainedBy() (*Chassis, error) {
+	if chassis.containedBy == "" {
+		return nil, nil
+	}
+
+	return GetChassis(chassis.Client, chassis.containedBy)
+}
+
+// ManagedByLinks returns the links to the managers of the chassis.
+func (chassis *Chassis) ManagedByLinks() []string {
+	return chassis.managedBy
+}
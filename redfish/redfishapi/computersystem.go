@@ -58,8 +58,12 @@ type ComputerSystem struct {
 	// ProcessorSummary shall contain properties which
 	// describe the central processors for the current resource.
 	ProcessorSummary ProcessorSummary
+	// PCIeDevices shall be the links to the PCIe devices of this system.
+	pcieDevices []string
 	// Processors shall be a link to a collection of type ProcessorCollection.
 	processors string
+	// chassis shall be the links to the chassis containing this system.
+	chassis []string
 	// Redundancy references a redundancy
 	// entity that specifies a kind and level of redundancy and a collection
 	// (RedundancySet) of other ComputerSystems that provide the specified
@@ -88,13 +92,16 @@ func (computersystem *ComputerSystem) UnmarshalJSON(b []byte) error {
 
 	type t_links struct {
 		Processors common.HpLink
+		Chassis    common.Links
 	}
 
 	var t struct {
 		temp
 		Processors         common.Link
+		EthernetInterfaces common.Link
 		Memory             common.Link
 		SimpleStorage      common.Link
+		PCIeDevices        common.Links
 		Links              t_links `json:"links"`
 	}
 
@@ -107,8 +114,11 @@ func (computersystem *ComputerSystem) UnmarshalJSON(b []byte) error {
 
 	// Extract the links to other entities for later
 	computersystem.processors = string(t.Processors)
+	computersystem.ethernetInterfaces = string(t.EthernetInterfaces)
 	computersystem.memory = string(t.Memory)
 	computersystem.simpleStorage = string(t.SimpleStorage)
+	computersystem.pcieDevices = t.PCIeDevices.ToStrings()
+	computersystem.chassis = t.Links.Chassis.ToStrings()
 
 
     if computersystem.Manufacturer != "" {
@@ -196,26 +206,14 @@ func GetComputerSystem(c common.Client, uri string) (*ComputerSystem, error) {
 // a provided reference.
 func ListReferencedComputerSystems(c common.Client, link string) ([]*ComputerSystem, error) {
 	var result []*ComputerSystem
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, computersystemLink := range links.ItemLinks {
-		computersystem, err := GetComputerSystem(c, computersystemLink)
-		if err != nil {
-			collectionError.Failures[computersystemLink] = err
-		} else {
-			result = append(result, computersystem)
-		}
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, computersystemLink string) (interface{}, error) {
+		return GetComputerSystem(c, computersystemLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*ComputerSystem))
 	}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return result, err
 }
 
 // MemorySummary contains properties which describe the central memory for a system.
@@ -258,4 +256,23 @@ func (computersystem *ComputerSystem) SimpleStorages() ([]*SimpleStorage, error)
 // Storage gets the storage associated with this system.
 func (computersystem *ComputerSystem) SmartStorages() ([]*SmartStorage, error) {
         return ListReferencedSmartStorages(computersystem.Client, computersystem.smartStorage)
-}
\ No newline at end of file
+}
+// EthernetInterfaces gets the ethernet interfaces of this system.
+func (computersystem *ComputerSystem) EthernetInterfaces() ([]*EthernetInterface, error) {
+        return ListReferencedEthernetInterfaces(computersystem.Client, computersystem.ethernetInterfaces)
+}
+
+// PCIeDevices gets the PCIe devices of this system.
+func (computersystem *ComputerSystem) PCIeDevices() ([]*PCIeDevice, error) {
+        return GetPCIeDevices(computersystem.Client, computersystem.pcieDevices)
+}
+
+// Memory gets the memory devices of this system.
+func (computersystem *ComputerSystem) Memory() ([]*Memory, error) {
+        return ListReferencedMemorys(computersystem.Client, computersystem.memory)
+}
+
+// Chassis gets the chassis containing the system
+func (computersystem *ComputerSystem) Chassis() ([]*Chassis, error) {
+	return GetChassises(computersystem.Client, computersystem.chassis)
+}
@@ -70,24 +70,12 @@ func ListReferencedDrives(c common.Client, link string) ([]*Drive, error) { //no
 		return result, nil
 	}
 
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range links.ItemLinks {
-		drive, err := GetDrive(c, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, driveLink string) (interface{}, error) {
+		return GetDrive(c, driveLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Drive))
 	}
 
-	return result, collectionError
+	return result, err
 }
\ No newline at end of file
@@ -0,0 +1,112 @@
+package redfishapi
+
+import (
+	"encoding/json"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// LinkStatus is the link status of an ethernet interface.
+type LinkStatus string
+
+const (
+	// LinkUpLinkStatus The link is available for communication on this interface.
+	LinkUpLinkStatus LinkStatus = "LinkUp"
+	// NoLinkLinkStatus There is no link or connection detected on this interface.
+	NoLinkLinkStatus LinkStatus = "NoLink"
+	// LinkDownLinkStatus There is no link on this interface, but the interface is connected.
+	LinkDownLinkStatus LinkStatus = "LinkDown"
+)
+
+// EthernetInterface is used to represent NIC resources.
+type EthernetInterface struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// AutoNeg shall be true if auto negotiation of speed and duplex is enabled
+	// on this interface and false if it is disabled.
+	AutoNeg bool
+	// Description provides a description of this resource.
+	Description string
+	// FQDN shall be the fully qualified domain name for this interface.
+	FQDN string
+	// FullDuplex shall represent the duplex status of the Ethernet connection
+	// on this interface.
+	FullDuplex bool
+	// HostName shall be host name for this interface.
+	HostName string
+	// InterfaceEnabled shall be a boolean indicating whether this interface is
+	// enabled.
+	InterfaceEnabled bool
+	// LinkStatus shall be the link status of this interface (port).
+	LinkStatus LinkStatus
+	// MACAddress shall be the effective current MAC Address of this interface.
+	MACAddress string
+	// MTUSize shall be the size in bytes of largest Protocol Data Unit (PDU)
+	// that can be passed in an Ethernet (MAC) frame on this interface.
+	MTUSize int
+	// PermanentMACAddress shall be the Permanent MAC Address of this interface
+	// (port).
+	PermanentMACAddress string
+	// SpeedMbps shall be the link speed of the interface in Mbps.
+	SpeedMbps int
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+}
+
+// UnmarshalJSON unmarshals an EthernetInterface object from the raw JSON.
+func (ethernetinterface *EthernetInterface) UnmarshalJSON(b []byte) error {
+	type temp EthernetInterface
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*ethernetinterface = EthernetInterface(t.temp)
+
+	return nil
+}
+
+// GetEthernetInterface will get an EthernetInterface instance from the service.
+func GetEthernetInterface(c common.Client, uri string) (*EthernetInterface, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var ethernetinterface EthernetInterface
+	err = json.NewDecoder(resp.Body).Decode(&ethernetinterface)
+	if err != nil {
+		return nil, err
+	}
+
+	ethernetinterface.SetClient(c)
+	return &ethernetinterface, nil
+}
+
+// ListReferencedEthernetInterfaces gets the collection of EthernetInterface
+// from a provided reference.
+func ListReferencedEthernetInterfaces(c common.Client, link string) ([]*EthernetInterface, error) { //nolint:dupl
+	var result []*EthernetInterface
+	if link == "" {
+		return result, nil
+	}
+
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, ethernetinterfaceLink string) (interface{}, error) {
+		return GetEthernetInterface(c, ethernetinterfaceLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*EthernetInterface))
+	}
+
+	return result, err
+}
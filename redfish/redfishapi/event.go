@@ -0,0 +1,55 @@
+package redfishapi
+
+import (
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// EventRecord is a single event of an Event payload.
+type EventRecord struct {
+	// Context shall contain a client supplied context for the subscription.
+	Context string
+	// EventID shall indicate a unique identifier for the event.
+	EventID string `json:"EventId"`
+	// EventTimestamp shall indicate the time the event occurred.
+	EventTimestamp string
+	// EventType shall indicate the type of event.
+	EventType EventType
+	// Message shall contain an optional human readable message.
+	Message string
+	// MessageArgs shall contain the message substitution arguments for the
+	// specific message referenced by the MessageId.
+	MessageArgs []string
+	// MessageID shall be the key into message registry as described in the
+	// Redfish specification.
+	MessageID string `json:"MessageId"`
+	// MessageSeverity shall be the severity of the message.
+	MessageSeverity common.Health
+	// OriginOfCondition shall contain a pointer consistent with JSON pointer
+	// syntax to the resource that caused the event to be generated.
+	OriginOfCondition common.Link
+	// Severity shall be the severity of the event, it is deprecated in favor
+	// of MessageSeverity.
+	Severity string
+}
+
+// EventSeverity returns the severity of the event record.
+func (record *EventRecord) EventSeverity() string {
+	if record.MessageSeverity != "" {
+		return string(record.MessageSeverity)
+	}
+	return record.Severity
+}
+
+// Event is the payload pushed by the event service to its subscribers.
+type Event struct {
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// ID uniquely identifies the resource.
+	ID string `json:"Id"`
+	// Name is the name of the resource or array element.
+	Name string
+	// Context shall contain a client supplied context for the subscription.
+	Context string
+	// Events shall contain an array of EventRecord objects.
+	Events []EventRecord
+}
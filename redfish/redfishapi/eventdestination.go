@@ -0,0 +1,71 @@
+package redfishapi
+
+import (
+	"encoding/json"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// EventDestination is used to represent the target of an event subscription,
+// including the types of events subscribed and context to provide to the
+// target in the Event payload.
+type EventDestination struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Context shall contain a client supplied context that will remain with
+	// the connection through the connections lifetime.
+	Context string
+	// Description provides a description of this resource.
+	Description string
+	// Destination shall contain a URI to the destination where the events will
+	// be sent.
+	Destination string
+	// EventTypes contains the types of events that will be sent to the
+	// destination.
+	EventTypes []EventType
+	// Protocol is used to indicate that the event type shall adhere to that
+	// defined in the Redfish specification.
+	Protocol string
+	// SubscriptionType shall indicate the type of subscription for events.
+	SubscriptionType string
+}
+
+// GetEventDestination will get an EventDestination instance from the service.
+func GetEventDestination(c common.Client, uri string) (*EventDestination, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var eventdestination EventDestination
+	err = json.NewDecoder(resp.Body).Decode(&eventdestination)
+	if err != nil {
+		return nil, err
+	}
+
+	eventdestination.SetClient(c)
+	return &eventdestination, nil
+}
+
+// ListReferencedEventDestinations gets the collection of EventDestination from
+// a provided reference.
+func ListReferencedEventDestinations(c common.Client, link string) ([]*EventDestination, error) { //nolint:dupl
+	var result []*EventDestination
+	if link == "" {
+		return result, nil
+	}
+
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, eventdestinationLink string) (interface{}, error) {
+		return GetEventDestination(c, eventdestinationLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*EventDestination))
+	}
+
+	return result, err
+}
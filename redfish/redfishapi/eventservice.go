@@ -0,0 +1,141 @@
+package redfishapi
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// EventType is the type of an event.
+type EventType string
+
+const (
+	// AlertEventType indicates a condition exists which requires attention.
+	AlertEventType EventType = "Alert"
+	// ResourceAddedEventType indicates a resource has been added.
+	ResourceAddedEventType EventType = "ResourceAdded"
+	// ResourceRemovedEventType indicates a resource has been removed.
+	ResourceRemovedEventType EventType = "ResourceRemoved"
+	// ResourceUpdatedEventType indicates a resource has been updated.
+	ResourceUpdatedEventType EventType = "ResourceUpdated"
+	// StatusChangeEventType indicates the status of this resource has changed.
+	StatusChangeEventType EventType = "StatusChange"
+)
+
+// EventService is used to represent an event service for a Redfish
+// implementation.
+type EventService struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// DeliveryRetryAttempts shall be the number of retries attempted for any
+	// given event to the subscription destination before the subscription is
+	// terminated.
+	DeliveryRetryAttempts int
+	// DeliveryRetryIntervalSeconds shall be the interval in seconds between
+	// the retry attempts for any given event to the subscription destination.
+	DeliveryRetryIntervalSeconds int
+	// Description provides a description of this resource.
+	Description string
+	// EventTypesForSubscription is the types of Events that can be subscribed to.
+	EventTypesForSubscription []EventType
+	// ServerSentEventURI shall be a URI that specifies an SSE stream for the
+	// event service.
+	ServerSentEventURI string `json:"ServerSentEventUri"`
+	// ServiceEnabled shall be a boolean indicating whether this service is enabled.
+	ServiceEnabled bool
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+	// subscriptions shall be a link to a collection of type EventDestination.
+	subscriptions string
+}
+
+// UnmarshalJSON unmarshals an EventService object from the raw JSON.
+func (eventservice *EventService) UnmarshalJSON(b []byte) error {
+	type temp EventService
+	var t struct {
+		temp
+		Subscriptions common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*eventservice = EventService(t.temp)
+
+	// Extract the links to other entities for later
+	eventservice.subscriptions = string(t.Subscriptions)
+
+	return nil
+}
+
+// GetEventService will get an EventService instance from the service.
+func GetEventService(c common.Client, uri string) (*EventService, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var eventservice EventService
+	err = json.NewDecoder(resp.Body).Decode(&eventservice)
+	if err != nil {
+		return nil, err
+	}
+
+	eventservice.SetClient(c)
+	return &eventservice, nil
+}
+
+// Subscriptions gets the event subscriptions of the event service.
+func (eventservice *EventService) Subscriptions() ([]*EventDestination, error) {
+	return ListReferencedEventDestinations(eventservice.Client, eventservice.subscriptions)
+}
+
+type subscriptionPayload struct {
+	Destination string      `json:"Destination"`
+	Context     string      `json:"Context,omitempty"`
+	Protocol    string      `json:"Protocol"`
+	EventTypes  []EventType `json:"EventTypes,omitempty"`
+}
+
+// CreateEventSubscription creates a subscription which pushes events to
+// destination and returns the URI of the new EventDestination.
+func (eventservice *EventService) CreateEventSubscription(destination, context string, eventTypes []EventType) (string, error) {
+	payload := &subscriptionPayload{
+		Destination: destination,
+		Context:     context,
+		Protocol:    "Redfish",
+		EventTypes:  eventTypes,
+	}
+
+	resp, err := eventservice.Client.Post(eventservice.subscriptions, payload)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	subscription := resp.Header.Get("Location")
+	if urlParser, err := url.ParseRequestURI(subscription); err == nil {
+		subscription = urlParser.RequestURI()
+	}
+
+	return subscription, nil
+}
+
+// DeleteEventSubscription deletes the EventDestination at the given URI.
+func (eventservice *EventService) DeleteEventSubscription(uri string) error {
+	resp, err := eventservice.Client.Delete(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,127 @@
+package redfishapi
+
+import (
+	"encoding/json"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// MemoryLocation shall contain properties which describe the Memory
+// connection information to sockets and memory controllers.
+type MemoryLocation struct {
+	// Channel shall be Channel number in which Memory is connected.
+	Channel int
+	// MemoryController shall be Memory controller number in which Memory is
+	// connected.
+	MemoryController int
+	// Slot shall be Slot number in which Memory is connected.
+	Slot int
+	// Socket shall be Socket number in which Memory is connected.
+	Socket int
+}
+
+// Memory is used to represent a single memory device, such as a DIMM.
+type Memory struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// CapacityMiB shall be the Memory capacity in MiB.
+	CapacityMiB int
+	// Description provides a description of this resource.
+	Description string
+	// DeviceLocator shall be location of the Memory in the platform, typically
+	// marked in the silk screen.
+	DeviceLocator string
+	// Manufacturer shall contain the manufacturer of the Memory.
+	Manufacturer string
+	// MemoryDeviceType shall be the Memory Device Type as defined by SMBIOS.
+	MemoryDeviceType string
+	// MemoryLocation shall contain properties which describe the Memory
+	// connection information to sockets and memory controllers.
+	MemoryLocation MemoryLocation
+	// OperatingSpeedMhz shall be the operating speed of Memory in MHz or MT/s
+	// (mega-transfers per second) as reported by the memory device.
+	OperatingSpeedMhz int
+	// PartNumber shall indicate the part number as provided by the manufacturer
+	// of this Memory.
+	PartNumber string
+	// SerialNumber shall indicate the serial number as provided by the
+	// manufacturer of this Memory.
+	SerialNumber string
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+	// metrics shall be a reference to the Metrics associated with this Memory.
+	metrics string
+}
+
+// UnmarshalJSON unmarshals a Memory object from the raw JSON.
+func (memory *Memory) UnmarshalJSON(b []byte) error {
+	type temp Memory
+	var t struct {
+		temp
+		Metrics common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*memory = Memory(t.temp)
+
+	// Extract the links to other entities for later
+	memory.metrics = string(t.Metrics)
+
+	return nil
+}
+
+// Metrics gets the metrics associated with this memory.
+func (memory *Memory) Metrics() (*MemoryMetrics, error) {
+	if memory.metrics == "" {
+		return nil, nil
+	}
+
+	return GetMemoryMetrics(memory.Client, memory.metrics)
+}
+
+// GetMemory will get a Memory instance from the service.
+func GetMemory(c common.Client, uri string) (*Memory, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var memory Memory
+	err = json.NewDecoder(resp.Body).Decode(&memory)
+	if err != nil {
+		return nil, err
+	}
+
+	memory.SetClient(c)
+	return &memory, nil
+}
+
+// memorySelect are the properties of the memory devices read with $select.
+var memorySelect = []string{"Id", "Name", "DeviceLocator", "Status", "CapacityMiB", "Metrics"}
+
+// ListReferencedMemorys gets the collection of Memory from a provided reference.
+func ListReferencedMemorys(c common.Client, link string) ([]*Memory, error) { //nolint:dupl
+	var result []*Memory
+	if link == "" {
+		return result, nil
+	}
+
+	members, err := common.ListCollection(c, link, memorySelect, func(c common.Client, memoryLink string) (interface{}, error) {
+		return GetMemory(c, memoryLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Memory))
+	}
+
+	return result, err
+}
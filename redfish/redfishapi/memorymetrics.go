@@ -0,0 +1,102 @@
+package redfishapi
+
+import (
+	"encoding/json"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// AlarmTrips shall contain properties describing the types of alarms that
+// have been raised by the memory. Alarms are nil when the service does not
+// report them.
+type AlarmTrips struct {
+	// AddressParityError shall be true if an Address Parity Error was detected
+	// which could not be corrected by retry.
+	AddressParityError *bool
+	// CorrectableECCError shall be true if the correctable error threshold
+	// crossing alarm trip was detected.
+	CorrectableECCError *bool
+	// SpareBlock shall be true if the spare block capacity crossing alarm trip
+	// was detected.
+	SpareBlock *bool
+	// Temperature shall be true if a temperature threshold alarm trip was
+	// detected.
+	Temperature *bool
+	// UncorrectableECCError shall be true if the uncorrectable error threshold
+	// alarm trip was detected.
+	UncorrectableECCError *bool
+}
+
+// MemoryHealthData shall contain properties which describe the HealthData
+// metrics for the current resource.
+type MemoryHealthData struct {
+	// AlarmTrips shall contain properties describe the types of alarms that
+	// have been raised by the memory.
+	AlarmTrips AlarmTrips
+	// DataLossDetected shall be data loss detection status, with true
+	// indicating data loss detected.
+	DataLossDetected bool
+	// PerformanceDegraded shall be performance degraded mode status, with
+	// true indicating performance degraded.
+	PerformanceDegraded bool
+	// PredictedMediaLifeLeftPercent shall contain an indicator of the
+	// percentage of life remaining in the media.
+	PredictedMediaLifeLeftPercent float32
+}
+
+// MemoryPeriodMetrics shall describe the memory metrics of a period of time.
+// Error counts are nil when the service does not report them.
+type MemoryPeriodMetrics struct {
+	// BlocksRead shall be number of blocks read.
+	BlocksRead int64
+	// BlocksWritten shall be number of blocks written.
+	BlocksWritten int64
+	// CorrectableECCErrorCount shall contain the number of correctable errors.
+	CorrectableECCErrorCount *int64
+	// UncorrectableECCErrorCount shall contain the number of uncorrectable
+	// errors.
+	UncorrectableECCErrorCount *int64
+}
+
+// MemoryMetrics is used to represent the Memory Metrics for a single Memory
+// device in a Redfish implementation.
+type MemoryMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// BlockSizeBytes shall be the block size in bytes of all structure
+	// elements.
+	BlockSizeBytes int
+	// CurrentPeriod shall contain properties which describe the memory
+	// metrics for the current period.
+	CurrentPeriod MemoryPeriodMetrics
+	// Description provides a description of this resource.
+	Description string
+	// HealthData shall contain properties which describe the HealthData
+	// metrics for the current resource.
+	HealthData MemoryHealthData
+	// LifeTime shall contain properties which describe the memory metrics for
+	// the lifetime of the memory.
+	LifeTime MemoryPeriodMetrics
+}
+
+// GetMemoryMetrics will get a MemoryMetrics instance from the service.
+func GetMemoryMetrics(c common.Client, uri string) (*MemoryMetrics, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var memorymetrics MemoryMetrics
+	err = json.NewDecoder(resp.Body).Decode(&memorymetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	memorymetrics.SetClient(c)
+	return &memorymetrics, nil
+}
@@ -0,0 +1,137 @@
+package redfishapi
+
+import (
+	"encoding/json"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// ControllerCapabilities shall describe the capabilities of a controller.
+type ControllerCapabilities struct {
+	// NetworkDeviceFunctionCount shall be the number of physical functions
+	// available on this controller.
+	NetworkDeviceFunctionCount int
+	// NetworkPortCount shall be the number of physical ports on this controller.
+	NetworkPortCount int
+}
+
+// Controllers shall describe a network controller ASIC that makes up part of
+// a NetworkAdapter.
+type Controllers struct {
+	// ControllerCapabilities shall contain the capabilities of this controller.
+	ControllerCapabilities ControllerCapabilities
+	// FirmwarePackageVersion shall be the version number of the user-facing
+	// firmware package.
+	FirmwarePackageVersion string
+	// Location shall contain location information of the associated network
+	// adapter controller.
+	Location common.Location
+}
+
+// NetworkAdapter is used to represent the physical network adapter capable of
+// connecting to a computer network. Examples include but are not limited to
+// Ethernet, Fibre Channel, and converged network adapters.
+type NetworkAdapter struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Controllers shall contain the set of network controllers ASICs that make
+	// up this NetworkAdapter.
+	Controllers []Controllers
+	// Description provides a description of this resource.
+	Description string
+	// Manufacturer shall contain a value that represents the manufacturer of
+	// the network adapter.
+	Manufacturer string
+	// Model shall contain the information about how the manufacturer refers to
+	// this network adapter.
+	Model string
+	// PartNumber shall contain the part number for the network adapter as
+	// defined by the manufacturer.
+	PartNumber string
+	// SKU shall contain the Stock Keeping Unit (SKU) for the network adapter.
+	SKU string
+	// SerialNumber shall contain the serial number for the network adapter.
+	SerialNumber string
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+	// networkPorts shall be a link to a collection of type NetworkPortCollection.
+	networkPorts string
+}
+
+// UnmarshalJSON unmarshals a NetworkAdapter object from the raw JSON.
+func (networkadapter *NetworkAdapter) UnmarshalJSON(b []byte) error {
+	type temp NetworkAdapter
+	var t struct {
+		temp
+		NetworkPorts common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*networkadapter = NetworkAdapter(t.temp)
+
+	// Extract the links to other entities for later
+	networkadapter.networkPorts = string(t.NetworkPorts)
+
+	return nil
+}
+
+// FirmwareVersion returns the firmware package version of the first
+// controller of the network adapter.
+func (networkadapter *NetworkAdapter) FirmwareVersion() string {
+	for _, controller := range networkadapter.Controllers {
+		if controller.FirmwarePackageVersion != "" {
+			return controller.FirmwarePackageVersion
+		}
+	}
+	return ""
+}
+
+// NetworkPorts gets the physical ports of this network adapter.
+func (networkadapter *NetworkAdapter) NetworkPorts() ([]*NetworkPort, error) {
+	return ListReferencedNetworkPorts(networkadapter.Client, networkadapter.networkPorts)
+}
+
+// GetNetworkAdapter will get a NetworkAdapter instance from the service.
+func GetNetworkAdapter(c common.Client, uri string) (*NetworkAdapter, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var networkadapter NetworkAdapter
+	err = json.NewDecoder(resp.Body).Decode(&networkadapter)
+	if err != nil {
+		return nil, err
+	}
+
+	networkadapter.SetClient(c)
+	return &networkadapter, nil
+}
+
+// ListReferencedNetworkAdapters gets the collection of NetworkAdapter from
+// a provided reference.
+func ListReferencedNetworkAdapters(c common.Client, link string) ([]*NetworkAdapter, error) { //nolint:dupl
+	var result []*NetworkAdapter
+	if link == "" {
+		return result, nil
+	}
+
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, networkadapterLink string) (interface{}, error) {
+		return GetNetworkAdapter(c, networkadapterLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*NetworkAdapter))
+	}
+
+	return result, err
+}
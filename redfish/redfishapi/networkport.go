@@ -0,0 +1,111 @@
+package redfishapi
+
+import (
+	"encoding/json"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// PortLinkStatus is the link status of a network port.
+type PortLinkStatus string
+
+const (
+	// UpPortLinkStatus This link on this interface is up.
+	UpPortLinkStatus PortLinkStatus = "Up"
+	// DownPortLinkStatus This link on this interface is down.
+	DownPortLinkStatus PortLinkStatus = "Down"
+)
+
+// NetworkPort represents a discrete physical port capable of connecting to a
+// network.
+type NetworkPort struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// ActiveLinkTechnology shall contain the configured link technology of
+	// this port.
+	ActiveLinkTechnology string
+	// AssociatedNetworkAddresses shall be an array of configured network
+	// addresses (MAC or WWN) that are associated with this Network Port.
+	AssociatedNetworkAddresses []string
+	// CurrentLinkSpeedMbps shall be the current configured link speed of this
+	// Network Port.
+	CurrentLinkSpeedMbps int
+	// Description provides a description of this resource.
+	Description string
+	// LinkStatus shall be the link status between this port and its link
+	// partner.
+	LinkStatus PortLinkStatus
+	// PhysicalPortNumber shall be the physical port number on the network
+	// adapter hardware that this Network Port corresponds to.
+	PhysicalPortNumber string
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+}
+
+// UnmarshalJSON unmarshals a NetworkPort object from the raw JSON.
+func (networkport *NetworkPort) UnmarshalJSON(b []byte) error {
+	type temp NetworkPort
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*networkport = NetworkPort(t.temp)
+
+	return nil
+}
+
+// MACAddress returns the first network address associated with this port.
+func (networkport *NetworkPort) MACAddress() string {
+	for _, address := range networkport.AssociatedNetworkAddresses {
+		if address != "" {
+			return address
+		}
+	}
+	return ""
+}
+
+// GetNetworkPort will get a NetworkPort instance from the service.
+func GetNetworkPort(c common.Client, uri string) (*NetworkPort, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var networkport NetworkPort
+	err = json.NewDecoder(resp.Body).Decode(&networkport)
+	if err != nil {
+		return nil, err
+	}
+
+	networkport.SetClient(c)
+	return &networkport, nil
+}
+
+// ListReferencedNetworkPorts gets the collection of NetworkPort from
+// a provided reference.
+func ListReferencedNetworkPorts(c common.Client, link string) ([]*NetworkPort, error) { //nolint:dupl
+	var result []*NetworkPort
+	if link == "" {
+		return result, nil
+	}
+
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, networkportLink string) (interface{}, error) {
+		return GetNetworkPort(c, networkportLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*NetworkPort))
+	}
+
+	return result, err
+}
@@ -0,0 +1,172 @@
+package redfishapi
+
+import (
+	"encoding/json"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// PCIeTypes is the PCIe generation of a device, slot or link.
+type PCIeTypes string
+
+const (
+	// Gen1PCIeTypes A PCIe v1.0 slot.
+	Gen1PCIeTypes PCIeTypes = "Gen1"
+	// Gen2PCIeTypes A PCIe v2.0 slot.
+	Gen2PCIeTypes PCIeTypes = "Gen2"
+	// Gen3PCIeTypes A PCIe v3.0 slot.
+	Gen3PCIeTypes PCIeTypes = "Gen3"
+	// Gen4PCIeTypes A PCIe v4.0 slot.
+	Gen4PCIeTypes PCIeTypes = "Gen4"
+	// Gen5PCIeTypes A PCIe v5.0 slot.
+	Gen5PCIeTypes PCIeTypes = "Gen5"
+)
+
+// PCIeInterface shall describe the PCIe interface of a device.
+type PCIeInterface struct {
+	// LanesInUse shall be the number of PCIe lanes in use by this device.
+	LanesInUse int
+	// MaxLanes shall be the maximum number of PCIe lanes supported by this device.
+	MaxLanes int
+	// MaxPCIeType shall be the highest version of the PCIe specification
+	// supported by this device.
+	MaxPCIeType PCIeTypes
+	// PCIeType shall be the negotiated PCIe interface version in use by this device.
+	PCIeType PCIeTypes
+}
+
+// PCIeDevice is used to represent a PCIe device attached to a system or
+// chassis, such as a GPU, HBA or NVMe card.
+type PCIeDevice struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// AssetTag is used to track the PCIe device for inventory purposes.
+	AssetTag string
+	// Description provides a description of this resource.
+	Description string
+	// DeviceType shall be the device type of the PCIe device such as
+	// SingleFunction or MultiFunction.
+	DeviceType string
+	// FirmwareVersion shall be the firmware version of the PCIe device.
+	FirmwareVersion string
+	// Manufacturer shall be the name of the organization responsible for
+	// producing the PCIe device.
+	Manufacturer string
+	// Model shall be the name by which the manufacturer generally refers to
+	// the PCIe device.
+	Model string
+	// PCIeInterface shall contain details on the PCIe interface used to
+	// connect this device to its host or upstream switch.
+	PCIeInterface PCIeInterface
+	// PartNumber shall be a part number assigned by the organization that is
+	// responsible for producing or manufacturing the PCIe device.
+	PartNumber string
+	// SKU shall be the stock-keeping unit number for this PCIe device.
+	SKU string
+	// SerialNumber is used to identify the PCIe device.
+	SerialNumber string
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+	// pcieFunctions shall be a link to a collection of type PCIeFunctionCollection.
+	pcieFunctions string
+	// pcieFunctionLinks shall be the links to the PCIe functions of older
+	// services which list them under Links.
+	pcieFunctionLinks []string
+}
+
+// UnmarshalJSON unmarshals a PCIeDevice object from the raw JSON.
+func (pciedevice *PCIeDevice) UnmarshalJSON(b []byte) error {
+	type temp PCIeDevice
+	var t struct {
+		temp
+		PCIeFunctions common.Link
+		Links         struct {
+			PCIeFunctions common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*pciedevice = PCIeDevice(t.temp)
+
+	// Extract the links to other entities for later
+	pciedevice.pcieFunctions = string(t.PCIeFunctions)
+	pciedevice.pcieFunctionLinks = t.Links.PCIeFunctions.ToStrings()
+
+	return nil
+}
+
+// PCIeFunctions gets the PCIe functions exposed by this device.
+func (pciedevice *PCIeDevice) PCIeFunctions() ([]*PCIeFunction, error) {
+	if pciedevice.pcieFunctions != "" {
+		return ListReferencedPCIeFunctions(pciedevice.Client, pciedevice.pcieFunctions)
+	}
+
+	var result []*PCIeFunction
+	members, err := common.FetchCollection(pciedevice.pcieFunctionLinks, func(pciefunctionLink string) (interface{}, error) {
+		return GetPCIeFunction(pciedevice.Client, pciefunctionLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*PCIeFunction))
+	}
+
+	return result, err
+}
+
+// GetPCIeDevice will get a PCIeDevice instance from the service.
+func GetPCIeDevice(c common.Client, uri string) (*PCIeDevice, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var pciedevice PCIeDevice
+	err = json.NewDecoder(resp.Body).Decode(&pciedevice)
+	if err != nil {
+		return nil, err
+	}
+
+	pciedevice.SetClient(c)
+	return &pciedevice, nil
+}
+
+// ListReferencedPCIeDevices gets the collection of PCIeDevice from
+// a provided reference.
+func ListReferencedPCIeDevices(c common.Client, link string) ([]*PCIeDevice, error) { //nolint:dupl
+	var result []*PCIeDevice
+	if link == "" {
+		return result, nil
+	}
+
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, pciedeviceLink string) (interface{}, error) {
+		return GetPCIeDevice(c, pciedeviceLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*PCIeDevice))
+	}
+
+	return result, err
+}
+
+// GetPCIeDevices gets the PCIeDevice instances of a list of links, as used by
+// resources that list their devices directly instead of in a collection.
+func GetPCIeDevices(c common.Client, links []string) ([]*PCIeDevice, error) {
+	var result []*PCIeDevice
+	members, err := common.FetchCollection(links, func(pciedeviceLink string) (interface{}, error) {
+		return GetPCIeDevice(c, pciedeviceLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*PCIeDevice))
+	}
+
+	return result, err
+}
@@ -0,0 +1,96 @@
+package redfishapi
+
+import (
+	"encoding/json"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// PCIeFunction is used to represent a PCIe function of a PCIe device.
+type PCIeFunction struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// ClassCode shall be the PCI Class Code of the PCIe device function.
+	ClassCode string
+	// Description provides a description of this resource.
+	Description string
+	// DeviceClass shall be the device class of the PCIe device function such
+	// as Storage, Network, Memory etc.
+	DeviceClass string
+	// DeviceID shall be the PCI Device ID of the PCIe device function.
+	DeviceID string `json:"DeviceId"`
+	// FunctionID shall the PCIe device function number within a given PCIe device.
+	FunctionID int `json:"FunctionId"`
+	// FunctionType shall be the function type of the PCIe device function such
+	// as Physical or Virtual.
+	FunctionType string
+	// RevisionID shall be the PCI Revision ID of the PCIe device function.
+	RevisionID string `json:"RevisionId"`
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+	// SubsystemID shall be the PCI Subsystem ID of the PCIe device function.
+	SubsystemID string `json:"SubsystemId"`
+	// SubsystemVendorID shall be the PCI Subsystem Vendor ID of the PCIe
+	// device function.
+	SubsystemVendorID string `json:"SubsystemVendorId"`
+	// VendorID shall be the PCI Vendor ID of the PCIe device function.
+	VendorID string `json:"VendorId"`
+}
+
+// UnmarshalJSON unmarshals a PCIeFunction object from the raw JSON.
+func (pciefunction *PCIeFunction) UnmarshalJSON(b []byte) error {
+	type temp PCIeFunction
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*pciefunction = PCIeFunction(t.temp)
+
+	return nil
+}
+
+// GetPCIeFunction will get a PCIeFunction instance from the service.
+func GetPCIeFunction(c common.Client, uri string) (*PCIeFunction, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var pciefunction PCIeFunction
+	err = json.NewDecoder(resp.Body).Decode(&pciefunction)
+	if err != nil {
+		return nil, err
+	}
+
+	pciefunction.SetClient(c)
+	return &pciefunction, nil
+}
+
+// ListReferencedPCIeFunctions gets the collection of PCIeFunction from
+// a provided reference.
+func ListReferencedPCIeFunctions(c common.Client, link string) ([]*PCIeFunction, error) { //nolint:dupl
+	var result []*PCIeFunction
+	if link == "" {
+		return result, nil
+	}
+
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, pciefunctionLink string) (interface{}, error) {
+		return GetPCIeFunction(c, pciefunctionLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*PCIeFunction))
+	}
+
+	return result, err
+}
@@ -0,0 +1,95 @@
+package redfishapi
+
+import (
+	"encoding/json"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// PCIeSlot shall describe a single PCIe slot of a chassis.
+type PCIeSlot struct {
+	// HotPluggable shall indicate whether this PCIe slot supports hotplug.
+	HotPluggable bool
+	// Lanes shall be the maximum number of PCIe lanes supported by the slot.
+	Lanes int
+	// Location shall contain location information of the PCIe slot.
+	Location common.Location
+	// PCIeType shall be the maximum PCIe specification that this slot supports.
+	PCIeType PCIeTypes
+	// SlotType shall be the slot type as specified by the PCIe specification,
+	// such as FullLength or HalfLength.
+	SlotType string
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+	// pcieDevices shall be the links to the PCIe devices installed in this slot.
+	pcieDevices []string
+}
+
+// UnmarshalJSON unmarshals a PCIeSlot object from the raw JSON.
+func (pcieslot *PCIeSlot) UnmarshalJSON(b []byte) error {
+	type temp PCIeSlot
+	var t struct {
+		temp
+		Links struct {
+			PCIeDevice common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*pcieslot = PCIeSlot(t.temp)
+
+	// Extract the links to other entities for later
+	pcieslot.pcieDevices = t.Links.PCIeDevice.ToStrings()
+
+	return nil
+}
+
+// PCIeDevices returns the links to the PCIe devices installed in this slot.
+func (pcieslot *PCIeSlot) PCIeDevices() []string {
+	return pcieslot.pcieDevices
+}
+
+// Occupied reports whether a device is installed in this slot.
+func (pcieslot *PCIeSlot) Occupied() bool {
+	if len(pcieslot.pcieDevices) > 0 {
+		return true
+	}
+	return pcieslot.Status.State == common.EnabledState
+}
+
+// PCIeSlots is used to represent the set of PCIe slots of a chassis.
+type PCIeSlots struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Slots shall contain an entry for each PCIe slot of the chassis.
+	Slots []PCIeSlot
+}
+
+// GetPCIeSlots will get a PCIeSlots instance from the service.
+func GetPCIeSlots(c common.Client, uri string) (*PCIeSlots, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var pcieslots PCIeSlots
+	err = json.NewDecoder(resp.Body).Decode(&pcieslots)
+	if err != nil {
+		return nil, err
+	}
+
+	pcieslots.SetClient(c)
+	return &pcieslots, nil
+}
@@ -167,26 +167,14 @@ func ListReferencedPowers(c common.Client, link string) ([]*Power, error) { //no
 		return result, nil
 	}
 
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, powerLink := range links.ItemLinks {
-		power, err := GetPower(c, powerLink)
-		if err != nil {
-			collectionError.Failures[powerLink] = err
-		} else {
-			result = append(result, power)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, powerLink string) (interface{}, error) {
+		return GetPower(c, powerLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Power))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // PowerControl is
@@ -0,0 +1,255 @@
+package redfishapi
+
+import (
+	"encoding/json"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// PowerAllocation shall contain the set of properties describing the
+// allocation of power for a subsystem.
+type PowerAllocation struct {
+	// AllocatedWatts shall contain the total power, in watt units, that has
+	// been allocated or budgeted to this subsystem.
+	AllocatedWatts float32
+	// RequestedWatts shall contain the amount of power, in watt units, that
+	// the subsystem currently requests to be budgeted for future use.
+	RequestedWatts float32
+}
+
+// PowerSubsystem shall represent a power subsystem for a Redfish
+// implementation. It replaces the deprecated Power resource.
+type PowerSubsystem struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Allocation shall contain the set of properties describing the
+	// allocation of power for this subsystem.
+	Allocation PowerAllocation
+	// CapacityWatts shall represent the total power capacity that can be
+	// allocated to this subsystem.
+	CapacityWatts float32
+	// Description provides a description of this resource.
+	Description string
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+	// powerSupplies shall be a link to a collection of type
+	// PowerSupplyCollection.
+	powerSupplies string
+}
+
+// UnmarshalJSON unmarshals a PowerSubsystem object from the raw JSON.
+func (powersubsystem *PowerSubsystem) UnmarshalJSON(b []byte) error {
+	type temp PowerSubsystem
+	var t struct {
+		temp
+		PowerSupplies common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*powersubsystem = PowerSubsystem(t.temp)
+
+	// Extract the links to other entities for later
+	powersubsystem.powerSupplies = string(t.PowerSupplies)
+
+	return nil
+}
+
+// GetPowerSubsystem will get a PowerSubsystem instance from the service.
+func GetPowerSubsystem(c common.Client, uri string) (*PowerSubsystem, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var powersubsystem PowerSubsystem
+	err = json.NewDecoder(resp.Body).Decode(&powersubsystem)
+	if err != nil {
+		return nil, err
+	}
+
+	powersubsystem.SetClient(c)
+	return &powersubsystem, nil
+}
+
+// PowerSupplies gets the power supplies of the power subsystem
+func (powersubsystem *PowerSubsystem) PowerSupplies() ([]*PowerSupplyUnit, error) {
+	return ListReferencedPowerSupplyUnits(powersubsystem.Client, powersubsystem.powerSupplies)
+}
+
+// PowerSupplyUnit shall represent a power supply unit of a PowerSubsystem.
+// It replaces the PowerSupply of the deprecated Power resource.
+type PowerSupplyUnit struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// EfficiencyRatings shall contain an array of efficiency ratings for
+	// this power supply.
+	EfficiencyRatings []json.RawMessage
+	// FirmwareVersion shall contain the firmware version as defined by the
+	// manufacturer for this power supply.
+	FirmwareVersion string
+	// HotPluggable shall indicate whether the device can be inserted or
+	// removed while the underlying equipment otherwise remains in its current
+	// operational state.
+	HotPluggable bool
+	// LineInputStatus shall contain the status of the power line input for
+	// this power supply.
+	LineInputStatus string
+	// Location shall contain location information of this power supply.
+	Location common.Location
+	// Manufacturer shall contain the name of the organization responsible for
+	// producing the power supply.
+	Manufacturer string
+	// Model shall contain the model information as defined by the
+	// manufacturer for this power supply.
+	Model string
+	// PartNumber shall contain the part number as defined by the manufacturer
+	// for this power supply.
+	PartNumber string
+	// PowerCapacityWatts shall contain the maximum amount of power, in watt
+	// units, that this power supply is rated to deliver.
+	PowerCapacityWatts float32
+	// PowerSupplyType shall contain the input power type (AC or DC) of this
+	// power supply.
+	PowerSupplyType PowerSupplyType
+	// SerialNumber shall contain the serial number as defined by the
+	// manufacturer for this power supply.
+	SerialNumber string
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+	// metrics shall be a link to a resource of type PowerSupplyMetrics.
+	metrics string
+}
+
+// UnmarshalJSON unmarshals a PowerSupplyUnit object from the raw JSON.
+func (powersupplyunit *PowerSupplyUnit) UnmarshalJSON(b []byte) error {
+	type temp PowerSupplyUnit
+	var t struct {
+		temp
+		Metrics common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*powersupplyunit = PowerSupplyUnit(t.temp)
+
+	// Extract the links to other entities for later
+	powersupplyunit.metrics = string(t.Metrics)
+
+	return nil
+}
+
+// GetPowerSupplyUnit will get a PowerSupplyUnit instance from the service.
+func GetPowerSupplyUnit(c common.Client, uri string) (*PowerSupplyUnit, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var powersupplyunit PowerSupplyUnit
+	err = json.NewDecoder(resp.Body).Decode(&powersupplyunit)
+	if err != nil {
+		return nil, err
+	}
+
+	powersupplyunit.SetClient(c)
+	return &powersupplyunit, nil
+}
+
+// ListReferencedPowerSupplyUnits gets the collection of PowerSupplyUnit from a provided reference.
+func ListReferencedPowerSupplyUnits(c common.Client, link string) ([]*PowerSupplyUnit, error) { //nolint:dupl
+	var result []*PowerSupplyUnit
+	if link == "" {
+		return result, nil
+	}
+
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, powersupplyunitLink string) (interface{}, error) {
+		return GetPowerSupplyUnit(c, powersupplyunitLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*PowerSupplyUnit))
+	}
+
+	return result, err
+}
+
+// Metrics gets the metrics of the power supply
+func (powersupplyunit *PowerSupplyUnit) Metrics() (*PowerSupplyMetrics, error) {
+	if powersupplyunit.metrics == "" {
+		return nil, nil
+	}
+
+	return GetPowerSupplyMetrics(powersupplyunit.Client, powersupplyunit.metrics)
+}
+
+// PowerSupplyMetrics shall be used to represent the metrics of a power
+// supply unit for a Redfish implementation.
+type PowerSupplyMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// FanSpeedPercent shall contain the fan speed, in percent units, for this
+	// power supply.
+	FanSpeedPercent SensorFanExcerpt
+	// InputCurrentAmps shall contain the input current, in ampere units, for
+	// this power supply.
+	InputCurrentAmps SensorExcerpt
+	// InputPowerWatts shall contain the input power, in watt units, for this
+	// power supply.
+	InputPowerWatts SensorPowerExcerpt
+	// InputVoltage shall contain the input voltage, in volt units, for this
+	// power supply.
+	InputVoltage SensorExcerpt
+	// OutputPowerWatts shall contain the total output power, in watt units,
+	// for this power supply.
+	OutputPowerWatts SensorPowerExcerpt
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+	// TemperatureCelsius shall contain the temperature, in degree Celsius
+	// units, for this power supply.
+	TemperatureCelsius SensorExcerpt
+}
+
+// GetPowerSupplyMetrics will get a PowerSupplyMetrics instance from the service.
+func GetPowerSupplyMetrics(c common.Client, uri string) (*PowerSupplyMetrics, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var powersupplymetrics PowerSupplyMetrics
+	err = json.NewDecoder(resp.Body).Decode(&powersupplymetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	powersupplymetrics.SetClient(c)
+	return &powersupplymetrics, nil
+}
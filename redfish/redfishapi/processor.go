@@ -7,6 +7,27 @@ import (
 	"github.com/magicst0ne/rackserver_exporter/redfish/common"
 )
 
+// ProcessorType is the type of processor.
+type ProcessorType string
+
+const (
+	// CPUProcessorType A Central Processing Unit.
+	CPUProcessorType ProcessorType = "CPU"
+	// GPUProcessorType A Graphics Processing Unit.
+	GPUProcessorType ProcessorType = "GPU"
+	// FPGAProcessorType A Field Programmable Gate Array.
+	FPGAProcessorType ProcessorType = "FPGA"
+	// DSPProcessorType A Digital Signal Processor.
+	DSPProcessorType ProcessorType = "DSP"
+	// AcceleratorProcessorType An Accelerator.
+	AcceleratorProcessorType ProcessorType = "Accelerator"
+	// CoreProcessorType A Core in a Processor.
+	CoreProcessorType ProcessorType = "Core"
+	// ThreadProcessorType A Thread in a Processor.
+	ThreadProcessorType ProcessorType = "Thread"
+	// OEMProcessorType An OEM-defined Processing Unit.
+	OEMProcessorType ProcessorType = "OEM"
+)
 
 // Processor is used to represent a single processor contained within a
 // system.
@@ -34,8 +55,17 @@ type Processor struct {
 	// Model shall indicate the model information as
 	// provided by the manufacturer of this processor.
 	Model string
+	// InstructionSet shall contain the string which identifies the
+	// instruction set of the processor contained in this socket.
+	InstructionSet string
+	// ProcessorArchitecture shall contain the string which identifies the
+	// architecture of the processor contained in this socket.
+	ProcessorArchitecture string
 	// ProcessorID shall contain identification information for this processor.
 	ProcessorID ProcessorID `json:"ProcessorId"`
+	// ProcessorType shall contain the string which identifies the type of
+	// processor contained in this Socket.
+	ProcessorType ProcessorType
 	// Socket shall contain the string which identifies the
 	// physical location or socket of the processor.
 	//czw remove
@@ -59,6 +89,7 @@ func (processor *Processor) UnmarshalJSON(b []byte) error {
 	type temp Processor
 	type t1 struct {
 		temp
+		Metrics common.Link
 	}
 	var t t1
 
@@ -82,7 +113,7 @@ func (processor *Processor) UnmarshalJSON(b []byte) error {
 		if t2.MaxSpeedMHz != "" {
 			bitSize := 32
 			mhz, err := strconv.ParseFloat(t2.MaxSpeedMHz, bitSize)
-			if err != nil {
+			if err == nil {
 				t.MaxSpeedMHz = float32(mhz)
 			}
 		}
@@ -90,9 +121,21 @@ func (processor *Processor) UnmarshalJSON(b []byte) error {
 
 	*processor = Processor(t.temp)
 
+	// Extract the links to other entities for later
+	processor.metrics = string(t.Metrics)
+
 	return nil
 }
 
+// Metrics gets the metrics associated with this processor.
+func (processor *Processor) Metrics() (*ProcessorMetrics, error) {
+	if processor.metrics == "" {
+		return nil, nil
+	}
+
+	return GetProcessorMetrics(processor.Client, processor.metrics)
+}
+
 // GetProcessor will get a Processor instance from the system
 func GetProcessor(c common.Client, uri string) (*Processor, error) {
 	resp, err := c.Get(uri)
@@ -111,29 +154,24 @@ func GetProcessor(c common.Client, uri string) (*Processor, error) {
 	return &processor, nil
 }
 
+// processorSelect are the properties of the processors read with $select.
+var processorSelect = []string{
+	"Id", "Name", "Model", "Manufacturer", "Status", "TotalCores", "TotalThreads",
+	"ProcessorType", "ProcessorArchitecture", "InstructionSet", "ProcessorId",
+	"MaxSpeedMHz", "MaxTDPWatts", "Metrics",
+}
+
 // ListReferencedProcessors gets the collection of Processor from a provided reference.
 func ListReferencedProcessors(c common.Client, link string) ([]*Processor, error) {
 	var result []*Processor
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, processorLink := range links.ItemLinks {
-		processor, err := GetProcessor(c, processorLink)
-		if err != nil {
-			collectionError.Failures[processorLink] = err
-		} else {
-			result = append(result, processor)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.ListCollection(c, link, processorSelect, func(c common.Client, processorLink string) (interface{}, error) {
+		return GetProcessor(c, processorLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Processor))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // ProcessorID shall contain identification information for a processor.
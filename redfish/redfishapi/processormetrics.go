@@ -0,0 +1,97 @@
+package redfishapi
+
+import (
+	"encoding/json"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// CacheMetrics shall contain properties that describe cache metrics of a
+// processor. Counts are nil when the service does not report them.
+type CacheMetrics struct {
+	// CorrectableECCErrorCount shall contain the number of correctable errors
+	// of cache memory since reset.
+	CorrectableECCErrorCount *int64
+	// UncorrectableECCErrorCount shall contain the number of uncorrectable
+	// errors of cache memory since reset.
+	UncorrectableECCErrorCount *int64
+}
+
+// CacheMetricsTotal shall contain properties that describe the metrics for
+// all of the cache memory of a processor.
+type CacheMetricsTotal struct {
+	// CurrentPeriod shall contain properties that describe the metrics for
+	// the current period of cache memory for this processor.
+	CurrentPeriod CacheMetrics
+	// LifeTime shall contain properties that describe the metrics for the
+	// lifetime of the cache memory for this processor.
+	LifeTime CacheMetrics
+}
+
+// ProcessorMetrics is used to represent the usage statistics of a processor
+// or accelerator. Error counts are nil when the service does not report them.
+type ProcessorMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// BandwidthPercent shall contain the bandwidth usage of the processor as
+	// a percentage.
+	BandwidthPercent float32
+	// CacheMetricsTotal shall contain properties that describe the metrics
+	// for all of the cache memory for this processor.
+	CacheMetricsTotal CacheMetricsTotal
+	// ConsumedPowerWatt shall contain the power, in watts, that the processor
+	// has consumed.
+	ConsumedPowerWatt float32
+	// CorrectableCoreErrorCount shall contain the number of correctable core
+	// errors, such as TLB or cache errors.
+	CorrectableCoreErrorCount *int64
+	// CorrectableOtherErrorCount shall contain the number of correctable
+	// errors of all other components.
+	CorrectableOtherErrorCount *int64
+	// Description provides a description of this resource.
+	Description string
+	// OperatingSpeedMHz shall contain the operating speed of the processor in
+	// MHz.
+	OperatingSpeedMHz int
+	// PowerLimitThrottleDuration shall contain the total duration of
+	// throttling caused by a power limit of the processor since reset.
+	PowerLimitThrottleDuration string
+	// TemperatureCelsius shall contain the temperature, in degrees Celsius,
+	// of the processor.
+	TemperatureCelsius float32
+	// ThermalLimitThrottleDuration shall contain the total duration of
+	// throttling caused by a thermal limit of the processor since reset.
+	ThermalLimitThrottleDuration string
+	// ThrottlingCelsius shall contain the CPU margin to throttle based on an
+	// offset between the maximum temperature in which the processor can
+	// operate, and the processor's current temperature.
+	ThrottlingCelsius float32
+	// UncorrectableCoreErrorCount shall contain the number of uncorrectable
+	// core errors, such as TLB or cache errors.
+	UncorrectableCoreErrorCount *int64
+	// UncorrectableOtherErrorCount shall contain the number of uncorrectable
+	// errors of all other components.
+	UncorrectableOtherErrorCount *int64
+}
+
+// GetProcessorMetrics will get a ProcessorMetrics instance from the service.
+func GetProcessorMetrics(c common.Client, uri string) (*ProcessorMetrics, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var processormetrics ProcessorMetrics
+	err = json.NewDecoder(resp.Body).Decode(&processormetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	processormetrics.SetClient(c)
+	return &processormetrics, nil
+}
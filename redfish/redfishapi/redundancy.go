@@ -173,24 +173,12 @@ func ListReferencedRedundancies(c common.Client, link string) ([]*Redundancy, er
 		return result, nil
 	}
 
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, redundancyLink := range links.ItemLinks {
-		redundancy, err := GetRedundancy(c, redundancyLink)
-		if err != nil {
-			collectionError.Failures[redundancyLink] = err
-		} else {
-			result = append(result, redundancy)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, redundancyLink string) (interface{}, error) {
+		return GetRedundancy(c, redundancyLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Redundancy))
 	}
 
-	return result, collectionError
+	return result, err
 }
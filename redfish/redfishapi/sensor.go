@@ -0,0 +1,192 @@
+package redfishapi
+
+import (
+	"encoding/json"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// ReadingType is the type of sensor.
+type ReadingType string
+
+const (
+	// TemperatureReadingType shall indicate a temperature measurement in degrees Celsius.
+	TemperatureReadingType ReadingType = "Temperature"
+	// HumidityReadingType shall indicate a relative humidity measurement in percent.
+	HumidityReadingType ReadingType = "Humidity"
+	// PowerReadingType shall indicate the arithmetic mean of product terms of
+	// instantaneous voltage and current values measured over integer number of
+	// line cycles for a circuit, in watts.
+	PowerReadingType ReadingType = "Power"
+	// VoltageReadingType shall indicate a measurement of the root mean square
+	// (RMS) of instantaneous voltage, in volts.
+	VoltageReadingType ReadingType = "Voltage"
+	// RotationalReadingType shall indicate a measurement of rotational frequency.
+	RotationalReadingType ReadingType = "Rotational"
+)
+
+// SensorExcerpt shall contain the reading and a link to the Sensor the
+// reading was taken from.
+type SensorExcerpt struct {
+	// DataSourceURI shall contain a URI to the resource that provides the data
+	// for this sensor.
+	DataSourceURI string `json:"DataSourceUri"`
+	// Reading shall contain the sensor value.
+	Reading float32
+}
+
+// SensorFanExcerpt shall contain the reading of a fan sensor.
+type SensorFanExcerpt struct {
+	// DataSourceURI shall contain a URI to the resource that provides the data
+	// for this sensor.
+	DataSourceURI string `json:"DataSourceUri"`
+	// Reading shall contain the sensor value.
+	Reading float32
+	// SpeedRPM shall contain a reading of the rotational speed of the device
+	// in revolutions per minute (RPM) units.
+	SpeedRPM float32
+}
+
+// SensorPowerExcerpt shall contain the reading of a power sensor.
+type SensorPowerExcerpt struct {
+	// DataSourceURI shall contain a URI to the resource that provides the data
+	// for this sensor.
+	DataSourceURI string `json:"DataSourceUri"`
+	// Reading shall contain the sensor value.
+	Reading float32
+	// ApparentVA shall contain the product of voltage (RMS) multiplied by
+	// current (RMS) for a circuit.
+	ApparentVA float32
+	// ReactiveVAR shall contain the arithmetic mean of product terms of
+	// instantaneous voltage and quadrature current measurements.
+	ReactiveVAR float32
+	// PowerFactor shall identify the quotient of real power (W) and apparent
+	// power (VA) for a circuit.
+	PowerFactor float32
+}
+
+// Threshold shall contain the properties for an individual threshold for
+// this sensor.
+type Threshold struct {
+	// Reading shall indicate the reading for this sensor that activates the
+	// threshold.
+	Reading float32
+}
+
+// Thresholds shall contain the set of thresholds that derive a sensor's
+// health and operational range.
+type Thresholds struct {
+	// LowerCaution shall contain the value at which the reading is below
+	// normal range.
+	LowerCaution Threshold
+	// LowerCritical shall contain the value at which the reading is below
+	// normal range but not yet fatal.
+	LowerCritical Threshold
+	// LowerFatal shall contain the value at which the reading is below normal
+	// range and fatal.
+	LowerFatal Threshold
+	// UpperCaution shall contain the value at which the reading is above
+	// normal range.
+	UpperCaution Threshold
+	// UpperCritical shall contain the value at which the reading is above
+	// normal range but not yet fatal.
+	UpperCritical Threshold
+	// UpperFatal shall contain the value at which the reading is above normal
+	// range and fatal.
+	UpperFatal Threshold
+}
+
+// Sensor shall represent a sensor for a Redfish implementation.
+type Sensor struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Location shall indicate the location information for this sensor.
+	Location common.Location
+	// PhysicalContext shall contain a description of the affected component
+	// or region within the equipment to which this sensor measurement applies.
+	PhysicalContext common.PhysicalContext
+	// Reading shall contain the sensor value.
+	Reading float32
+	// ReadingRangeMax shall indicate the maximum possible value of the Reading
+	// property for this sensor.
+	ReadingRangeMax float32
+	// ReadingRangeMin shall indicate the minimum possible value of the Reading
+	// property for this sensor.
+	ReadingRangeMin float32
+	// ReadingType shall contain the type of the sensor.
+	ReadingType ReadingType
+	// ReadingUnits shall contain the units of the sensor's reading and
+	// thresholds.
+	ReadingUnits string
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+	// Thresholds shall contain the set of thresholds that derive a sensor's
+	// health and operational range.
+	Thresholds Thresholds
+}
+
+// GetSensor will get a Sensor instance from the service.
+func GetSensor(c common.Client, uri string) (*Sensor, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var sensor Sensor
+	err = json.NewDecoder(resp.Body).Decode(&sensor)
+	if err != nil {
+		return nil, err
+	}
+
+	sensor.SetClient(c)
+	return &sensor, nil
+}
+
+// sensorSelect are the properties of the sensors read with $select.
+var sensorSelect = []string{
+	"Id", "Name", "PhysicalContext", "Reading", "ReadingType", "ReadingUnits", "Status",
+}
+
+// ListExpandedSensors gets the collection of Sensor from a provided reference
+// if the service expands it, a chassis has too many sensors to read them one
+// by one. It reports false if the collection was not read.
+func ListExpandedSensors(c common.Client, link string) ([]*Sensor, bool, error) {
+	var result []*Sensor
+	if link == "" {
+		return result, false, nil
+	}
+
+	members, ok, err := common.ListExpandedCollection(c, link, sensorSelect, func(c common.Client, sensorLink string) (interface{}, error) {
+		return GetSensor(c, sensorLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Sensor))
+	}
+
+	return result, ok, err
+}
+
+// ListReferencedSensors gets the collection of Sensor from a provided reference.
+func ListReferencedSensors(c common.Client, link string) ([]*Sensor, error) { //nolint:dupl
+	var result []*Sensor
+	if link == "" {
+		return result, nil
+	}
+
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, sensorLink string) (interface{}, error) {
+		return GetSensor(c, sensorLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Sensor))
+	}
+
+	return result, err
+}
@@ -99,6 +99,49 @@ type Service struct {
 	Vendor string
 	// Sessions shall contain the link to a collection of Sessions.
 	sessions string
+	// ProtocolFeaturesSupported contains information about protocol features
+	// supported by the service.
+	ProtocolFeaturesSupported ProtocolFeaturesSupported
+}
+
+// ProtocolFeaturesSupported contains information about protocol features
+// supported by the service.
+type ProtocolFeaturesSupported struct {
+	// ExcerptQuery shall indicate whether the service supports the excerpt
+	// query parameter.
+	ExcerptQuery bool
+	// ExpandQuery shall contain information about the support of the $expand
+	// query parameter by the service.
+	ExpandQuery ExpandQuery
+	// FilterQuery shall indicate whether the service supports the $filter
+	// query parameter.
+	FilterQuery bool
+	// OnlyMemberQuery shall indicate whether the service supports the only
+	// query parameter.
+	OnlyMemberQuery bool
+	// SelectQuery shall indicate whether the service supports the $select
+	// query parameter.
+	SelectQuery bool
+}
+
+// ExpandQuery contains information about the support of the $expand query
+// parameter by the service.
+type ExpandQuery struct {
+	// ExpandAll shall indicate whether the service supports the asterisk
+	// (*) option of the $expand query parameter.
+	ExpandAll bool
+	// Levels shall indicate whether the service supports the $levels option
+	// of the $expand query parameter.
+	Levels bool
+	// Links shall indicate whether the service supports the tilde (~) option
+	// of the $expand query parameter.
+	Links bool
+	// MaxLevels shall contain the maximum $levels option value in the $expand
+	// query parameter.
+	MaxLevels int
+	// NoLinks shall indicate whether the service supports the period (.)
+	// option of the $expand query parameter.
+	NoLinks bool
 }
 
 // UnmarshalJSON unmarshals a Service object from the raw JSON.
@@ -207,6 +250,24 @@ func (serviceroot *Service) Chassis() ([]*Chassis, error) {
         return ListReferencedChassis(serviceroot.Client, serviceroot.chassis)
 }
 
+// EventService gets the event service instance from the service
+func (serviceroot *Service) EventService() (*EventService, error) {
+	if serviceroot.eventService == "" {
+		return nil, nil
+	}
+
+	return GetEventService(serviceroot.Client, serviceroot.eventService)
+}
+
+// UpdateService gets the update service instance from the service
+func (serviceroot *Service) UpdateService() (*UpdateService, error) {
+	if serviceroot.updateService == "" {
+		return nil, nil
+	}
+
+	return GetUpdateService(serviceroot.Client, serviceroot.updateService)
+}
+
 func DumpObj(obj interface{}) {
 
     empJSON, err := json.MarshalIndent(obj, "", "  ")
@@ -97,24 +97,12 @@ func ListReferencedSimpleStorages(c common.Client, link string) ([]*SimpleStorag
 		return result, nil
 	}
 
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, simplestorageLink := range links.ItemLinks {
-		simplestorage, err := GetSimpleStorage(c, simplestorageLink)
-		if err != nil {
-			collectionError.Failures[simplestorageLink] = err
-		} else {
-			result = append(result, simplestorage)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, simplestorageLink string) (interface{}, error) {
+		return GetSimpleStorage(c, simplestorageLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*SimpleStorage))
 	}
 
-	return result, collectionError
+	return result, err
 }
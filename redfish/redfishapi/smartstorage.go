@@ -88,24 +88,12 @@ func ListReferencedSmartStorages(c common.Client, link string) ([]*SmartStorage,
 
 	link = fmt.Sprintf("%sArrayControllers/", link)
 
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, smartstorageLink := range links.ItemLinks {
-		smartstorage, err := GetSmartStorage(c, smartstorageLink)
-		if err != nil {
-			collectionError.Failures[smartstorageLink] = err
-		} else {
-			result = append(result, smartstorage)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, smartstorageLink string) (interface{}, error) {
+		return GetSmartStorage(c, smartstorageLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*SmartStorage))
 	}
 
-	return result, collectionError
+	return result, err
 }
@@ -0,0 +1,105 @@
+package redfishapi
+
+import (
+	"encoding/json"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// SoftwareInventory is used to represent a single firmware or software
+// component, such as the BIOS, the BMC or the firmware of a NIC, RAID
+// controller, power supply or drive.
+type SoftwareInventory struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// LowestSupportedVersion shall represent the lowest supported version
+	// of this software.
+	LowestSupportedVersion string
+	// Manufacturer shall represent the name of the manufacturer or producer
+	// of this software.
+	Manufacturer string
+	// ReleaseDate shall be the date of release or production for this software.
+	ReleaseDate string
+	// SoftwareID shall represent an implementation-specific label that
+	// identifies this software.
+	SoftwareID string `json:"SoftwareId"`
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+	// Updateable shall indicate whether the Update Service can update this
+	// software.
+	Updateable bool
+	// Version shall contain the version of this software.
+	Version string
+	// relatedItems shall be the links to the resources this software is
+	// associated with.
+	relatedItems []string
+}
+
+// UnmarshalJSON unmarshals a SoftwareInventory object from the raw JSON.
+func (softwareInventory *SoftwareInventory) UnmarshalJSON(b []byte) error {
+	type temp SoftwareInventory
+	var t struct {
+		temp
+		RelatedItem common.Links
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*softwareInventory = SoftwareInventory(t.temp)
+
+	// Extract the links to other entities for later
+	softwareInventory.relatedItems = t.RelatedItem.ToStrings()
+
+	return nil
+}
+
+// RelatedItems returns the links to the resources this software belongs to.
+func (softwareInventory *SoftwareInventory) RelatedItems() []string {
+	return softwareInventory.relatedItems
+}
+
+// GetSoftwareInventory will get a SoftwareInventory instance from the service.
+func GetSoftwareInventory(c common.Client, uri string) (*SoftwareInventory, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var softwareInventory SoftwareInventory
+	err = json.NewDecoder(resp.Body).Decode(&softwareInventory)
+	if err != nil {
+		return nil, err
+	}
+
+	softwareInventory.SetClient(c)
+	return &softwareInventory, nil
+}
+
+// ListReferencedSoftwareInventories gets the collection of SoftwareInventory
+// from a provided reference.
+func ListReferencedSoftwareInventories(c common.Client, link string) ([]*SoftwareInventory, error) { //nolint:dupl
+	var result []*SoftwareInventory
+	if link == "" {
+		return result, nil
+	}
+
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, softwareInventoryLink string) (interface{}, error) {
+		return GetSoftwareInventory(c, softwareInventoryLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*SoftwareInventory))
+	}
+
+	return result, err
+}
@@ -23,6 +23,9 @@ type Fan struct {
 	common.Entity
 	// assembly shall be a link to a resource of type Assembly.
 	assembly string
+	// DataSourceURI shall contain a URI to the resource that provides the data
+	// for this fan.
+	DataSourceURI string `json:"DataSourceUri"`
 	// HotPluggable shall indicate whether the
 	// device can be inserted or removed while the underlying equipment
 	// otherwise remains in its current operational state. Devices indicated
@@ -170,6 +173,9 @@ type Temperature struct {
 	// environmental conditions present. For example, liquid inlet
 	// temperature may be adjusted based on the available liquid pressure.
 	AdjustedMinAllowableOperatingValue float32
+	// DataSourceURI shall contain a URI to the resource that provides the data
+	// for this temperature sensor.
+	DataSourceURI string `json:"DataSourceUri"`
 	// DeltaPhysicalContext shall be a description of the affected device or
 	// region within the chassis to which the DeltaReadingCelsius temperature
 	// measurement applies, relative to PhysicalContext.
@@ -344,24 +350,12 @@ func ListReferencedThermals(c common.Client, link string) ([]*Thermal, error) {
 		return result, nil
 	}
 
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, thermalLink := range links.ItemLinks {
-		thermal, err := GetThermal(c, thermalLink)
-		if err != nil {
-			collectionError.Failures[thermalLink] = err
-		} else {
-			result = append(result, thermal)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, thermalLink string) (interface{}, error) {
+		return GetThermal(c, thermalLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Thermal))
 	}
 
-	return result, collectionError
+	return result, err
 }
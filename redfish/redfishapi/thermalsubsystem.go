@@ -0,0 +1,153 @@
+package redfishapi
+
+import (
+	"encoding/json"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// ThermalSubsystem shall represent a thermal subsystem for a Redfish
+// implementation. It replaces the deprecated Thermal resource.
+type ThermalSubsystem struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// FanRedundancy shall contain redundancy information for the groups of
+	// fans in this subsystem.
+	FanRedundancy []json.RawMessage
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+	// fans shall be a link to a collection of type FanCollection.
+	fans string
+	// thermalMetrics shall be a link to a resource of type ThermalMetrics.
+	thermalMetrics string
+}
+
+// UnmarshalJSON unmarshals a ThermalSubsystem object from the raw JSON.
+func (thermalsubsystem *ThermalSubsystem) UnmarshalJSON(b []byte) error {
+	type temp ThermalSubsystem
+	var t struct {
+		temp
+		Fans           common.Link
+		ThermalMetrics common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*thermalsubsystem = ThermalSubsystem(t.temp)
+
+	// Extract the links to other entities for later
+	thermalsubsystem.fans = string(t.Fans)
+	thermalsubsystem.thermalMetrics = string(t.ThermalMetrics)
+
+	return nil
+}
+
+// GetThermalSubsystem will get a ThermalSubsystem instance from the service.
+func GetThermalSubsystem(c common.Client, uri string) (*ThermalSubsystem, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var thermalsubsystem ThermalSubsystem
+	err = json.NewDecoder(resp.Body).Decode(&thermalsubsystem)
+	if err != nil {
+		return nil, err
+	}
+
+	thermalsubsystem.SetClient(c)
+	return &thermalsubsystem, nil
+}
+
+// Fans gets the fans of the thermal subsystem
+func (thermalsubsystem *ThermalSubsystem) Fans() ([]*CoolingFan, error) {
+	return ListReferencedCoolingFans(thermalsubsystem.Client, thermalsubsystem.fans)
+}
+
+// CoolingFan shall represent a cooling fan of a ThermalSubsystem. It
+// replaces the Fan of the deprecated Thermal resource.
+type CoolingFan struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// HotPluggable shall indicate whether the device can be inserted or
+	// removed while the underlying equipment otherwise remains in its current
+	// operational state.
+	HotPluggable bool
+	// Location shall contain location information of this fan.
+	Location common.Location
+	// Manufacturer shall contain the name of the organization responsible for
+	// producing the fan.
+	Manufacturer string
+	// Model shall contain the model information as defined by the manufacturer
+	// for this fan.
+	Model string
+	// PartNumber shall contain the part number as defined by the manufacturer
+	// for this fan.
+	PartNumber string
+	// PhysicalContext shall contain a description of the affected device or
+	// region within the chassis with which this fan is associated.
+	PhysicalContext common.PhysicalContext
+	// PowerWatts shall contain the total power, in watt units, for this fan.
+	PowerWatts SensorPowerExcerpt
+	// SerialNumber shall contain the serial number as defined by the
+	// manufacturer for this fan.
+	SerialNumber string
+	// SpeedPercent shall contain the fan speed, in percent units, for this
+	// fan.
+	SpeedPercent SensorFanExcerpt
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+}
+
+// GetCoolingFan will get a CoolingFan instance from the service.
+func GetCoolingFan(c common.Client, uri string) (*CoolingFan, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var fan CoolingFan
+	err = json.NewDecoder(resp.Body).Decode(&fan)
+	if err != nil {
+		return nil, err
+	}
+
+	fan.SetClient(c)
+	return &fan, nil
+}
+
+// ListReferencedCoolingFans gets the collection of CoolingFan from a provided reference.
+func ListReferencedCoolingFans(c common.Client, link string) ([]*CoolingFan, error) { //nolint:dupl
+	var result []*CoolingFan
+	if link == "" {
+		return result, nil
+	}
+
+	members, err := common.ListCollection(c, link, nil, func(c common.Client, fanLink string) (interface{}, error) {
+		return GetCoolingFan(c, fanLink)
+	})
+	for _, member := range members {
+		result = append(result, member.(*CoolingFan))
+	}
+
+	return result, err
+}
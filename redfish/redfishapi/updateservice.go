@@ -0,0 +1,85 @@
+package redfishapi
+
+import (
+	"encoding/json"
+
+	"github.com/magicst0ne/rackserver_exporter/redfish/common"
+)
+
+// UpdateService is used to represent the update service offered by the
+// Redfish service.
+type UpdateService struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// FirmwareInventory shall be a link to a collection of
+	// SoftwareInventory resources describing the firmware of the service.
+	firmwareInventory string
+	// HTTPPushURI shall contain a URI at which the UpdateService supports
+	// push style firmware updates.
+	HTTPPushURI string `json:"HttpPushUri"`
+	// ServiceEnabled shall indicate whether this service is enabled.
+	ServiceEnabled bool
+	// SoftwareInventory shall be a link to a collection of
+	// SoftwareInventory resources describing the software of the service.
+	softwareInventory string
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+}
+
+// UnmarshalJSON unmarshals a UpdateService object from the raw JSON.
+func (updateService *UpdateService) UnmarshalJSON(b []byte) error {
+	type temp UpdateService
+	var t struct {
+		temp
+		FirmwareInventory common.Link
+		SoftwareInventory common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*updateService = UpdateService(t.temp)
+
+	// Extract the links to other entities for later
+	updateService.firmwareInventory = string(t.FirmwareInventory)
+	updateService.softwareInventory = string(t.SoftwareInventory)
+
+	return nil
+}
+
+// GetUpdateService will get an UpdateService instance from the service.
+func GetUpdateService(c common.Client, uri string) (*UpdateService, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var updateService UpdateService
+	err = json.NewDecoder(resp.Body).Decode(&updateService)
+	if err != nil {
+		return nil, err
+	}
+
+	updateService.SetClient(c)
+	return &updateService, nil
+}
+
+// FirmwareInventories gets the firmware components known to the update service.
+func (updateService *UpdateService) FirmwareInventories() ([]*SoftwareInventory, error) {
+	return ListReferencedSoftwareInventories(updateService.Client, updateService.firmwareInventory)
+}
+
+// SoftwareInventories gets the software components known to the update service.
+func (updateService *UpdateService) SoftwareInventories() ([]*SoftwareInventory, error) {
+	return ListReferencedSoftwareInventories(updateService.Client, updateService.softwareInventory)
+}
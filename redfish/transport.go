@@ -0,0 +1,136 @@
+package redfish
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxIdleConnsPerTarget is the number of idle connections kept open to each
+// target, enough for the collectors fetching in parallel.
+const maxIdleConnsPerTarget = 8
+
+// transportSettings are the parts of a ClientConfig a transport is built
+// from. Clients with the same endpoint and settings share a transport.
+type transportSettings struct {
+	insecure            bool
+	caFile              string
+	serverName          string
+	certFile            string
+	keyFile             string
+	minVersion          uint16
+	tlsHandshakeTimeout int
+	disableKeepAlives   bool
+}
+
+func newTransportSettings(config *ClientConfig) transportSettings {
+	return transportSettings{
+		insecure:            config.Insecure,
+		caFile:              config.TLSCAFile,
+		serverName:          config.TLSServerName,
+		certFile:            config.TLSCertFile,
+		keyFile:             config.TLSKeyFile,
+		minVersion:          config.TLSMinVersion,
+		tlsHandshakeTimeout: config.TLSHandshakeTimeout,
+		disableKeepAlives:   config.DisableKeepAlives,
+	}
+}
+
+// filesModified returns the modification times of the TLS files of config,
+// so rotated certificates are loaded.
+func filesModified(config *ClientConfig) [3]time.Time {
+	var modified [3]time.Time
+	for i, file := range []string{config.TLSCAFile, config.TLSCertFile, config.TLSKeyFile} {
+		if file == "" {
+			continue
+		}
+		if info, err := os.Stat(file); err == nil {
+			modified[i] = info.ModTime()
+		}
+	}
+	return modified
+}
+
+// transportKey identifies the clients sharing a transport, the groups of a
+// target may use different TLS settings.
+type transportKey struct {
+	endpoint string
+	settings transportSettings
+}
+
+type sharedTransport struct {
+	filesModified [3]time.Time
+	transport     *http.Transport
+}
+
+// transports holds the sharedTransport of every endpoint and settings.
+var (
+	transportsMutex sync.Mutex
+	transports      = make(map[transportKey]*sharedTransport)
+)
+
+// transportFor returns the transport shared by the clients of the endpoint
+// of config with the same settings, replacing it if its TLS files changed.
+func transportFor(config *ClientConfig) (*http.Transport, error) {
+	key := transportKey{endpoint: config.Endpoint, settings: newTransportSettings(config)}
+	modified := filesModified(config)
+
+	transportsMutex.Lock()
+	defer transportsMutex.Unlock()
+
+	if shared, ok := transports[key]; ok {
+		if shared.filesModified == modified {
+			return shared.transport, nil
+		}
+		shared.transport.CloseIdleConnections()
+	}
+
+	tlsConfig, err := newTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultTransport := http.DefaultTransport.(*http.Transport)
+	transport := &http.Transport{
+		Proxy:                 defaultTransport.Proxy,
+		DialContext:           defaultTransport.DialContext,
+		MaxIdleConns:          defaultTransport.MaxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConnsPerTarget,
+		IdleConnTimeout:       defaultTransport.IdleConnTimeout,
+		ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
+		TLSHandshakeTimeout:   time.Duration(config.TLSHandshakeTimeout) * time.Second,
+		TLSClientConfig:       tlsConfig,
+		DisableKeepAlives:     config.DisableKeepAlives,
+	}
+	transports[key] = &sharedTransport{filesModified: modified, transport: transport}
+
+	return transport, nil
+}
+
+// clientTrace returns the trace reporting the connections of the requests
+// to the observers of c, or nil if there are none.
+func (c *APIClient) clientTrace() *httptrace.ClientTrace {
+	if c.observeConnection == nil && c.observeTLSHandshake == nil {
+		return nil
+	}
+
+	var handshakeStart time.Time
+	return &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if c.observeConnection != nil {
+				c.observeConnection(info.Reused)
+			}
+		},
+		TLSHandshakeStart: func() {
+			handshakeStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			if c.observeTLSHandshake != nil && !handshakeStart.IsZero() {
+				c.observeTLSHandshake(time.Since(handshakeStart))
+			}
+		},
+	}
+}
@@ -0,0 +1,85 @@
+package redfish
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSharedTransport(t *testing.T) {
+	for _, disableKeepAlives := range []bool{false, true} {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{}`))
+		}))
+
+		var reusedCount, handshakeCount int32
+		config := ClientConfig{
+			Endpoint:          server.URL,
+			Insecure:          true,
+			DisableKeepAlives: disableKeepAlives,
+			ObserveConnection: func(r bool) {
+				if r {
+					atomic.AddInt32(&reusedCount, 1)
+				}
+			},
+			ObserveTLSHandshake: func(time.Duration) { atomic.AddInt32(&handshakeCount, 1) },
+		}
+
+		// two scrapes of two requests each, the service root and a get
+		var transports []http.RoundTripper
+		for i := 0; i < 2; i++ {
+			c, err := Connect(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := c.Get("/redfish/v1/Systems")
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			transports = append(transports, c.HTTPClient.Transport)
+		}
+
+		if transports[0] != transports[1] {
+			t.Errorf("keep-alive disabled %t: transport not shared by the clients of the endpoint", disableKeepAlives)
+		}
+		// the transport may dial a spare connection while another one is
+		// returned to the pool, so only the lack of reuse is exact
+		reused, handshakes := atomic.LoadInt32(&reusedCount), atomic.LoadInt32(&handshakeCount)
+		if disableKeepAlives {
+			if reused != 0 || handshakes != 4 {
+				t.Errorf("keep-alive disabled: got %d reused connections and %d handshakes, want 0 and 4", reused, handshakes)
+			}
+		} else if reused == 0 || handshakes > 2 {
+			t.Errorf("keep-alive enabled: got %d reused connections and %d handshakes", reused, handshakes)
+		}
+
+		server.Close()
+	}
+}
+
+func TestTransportPerSettings(t *testing.T) {
+	insecure := &ClientConfig{Endpoint: "https://10.0.0.1", Insecure: true}
+	verified := &ClientConfig{Endpoint: "https://10.0.0.1", TLSServerName: "bmc.example.com"}
+
+	// the groups of a target alternate without rebuilding their transports
+	var first [2]*http.Transport
+	for i := 0; i < 2; i++ {
+		for j, config := range []*ClientConfig{insecure, verified} {
+			transport, err := transportFor(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if i == 0 {
+				first[j] = transport
+			} else if transport != first[j] {
+				t.Errorf("transport of settings %d rebuilt by the scrape of the other settings", j)
+			}
+		}
+	}
+	if first[0] == first[1] {
+		t.Errorf("transport shared by clients with different TLS settings")
+	}
+}
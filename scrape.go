@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/magicst0ne/rackserver_exporter/collector"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// ScrapeConfig configures how scrapes of the same target share collections.
+type ScrapeConfig struct {
+	// Freshness is how long the result of a collection is served to later
+	// scrapes of the same target. Concurrent scrapes always share one.
+	Freshness time.Duration `yaml:"freshness"`
+	// RefreshIntervals are how long the metrics of the readings and
+	// inventory tiers are re-emitted before they are collected again. Tiers
+	// without an interval are collected on every scrape.
+	RefreshIntervals map[string]time.Duration `yaml:"refresh_intervals"`
+}
+
+// validate checks the freshness and the refresh intervals of the tiers.
+func (c *ScrapeConfig) validate() error {
+	if c.Freshness < 0 {
+		return fmt.Errorf("freshness must not be negative")
+	}
+	for tier, interval := range c.RefreshIntervals {
+		if tier != collector.ReadingsTier && tier != collector.InventoryTier {
+			return fmt.Errorf("unknown tier %s in refresh_intervals", tier)
+		}
+		if interval < 0 {
+			return fmt.Errorf("refresh interval of %s must not be negative", tier)
+		}
+	}
+	return nil
+}
+
+var scrapesCoalesced = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "rackserver",
+	Subsystem: "exporter",
+	Name:      "scrapes_coalesced_total",
+	Help:      "Scrapes served with the collection of another scrape of the same target.",
+})
+
+// scrapeKey identifies the scrapes which can share a collection.
+type scrapeKey struct {
+	target string
+	group  string
+}
+
+// scrapeCall is a collection, in flight until done is closed.
+type scrapeCall struct {
+	done     chan struct{}
+	families []*dto.MetricFamily
+	err      error
+}
+
+// scrapeGroup coalesces the collections of concurrent scrapes of the same
+// target, like singleflight, and keeps their result for the freshness window.
+type scrapeGroup struct {
+	mutex sync.Mutex
+	calls map[scrapeKey]*scrapeCall
+}
+
+func newScrapeGroup() *scrapeGroup {
+	return &scrapeGroup{calls: make(map[scrapeKey]*scrapeCall)}
+}
+
+// Do returns the result of gather for key, sharing the in-flight or fresh
+// result of an earlier call.
+func (g *scrapeGroup) Do(key scrapeKey, freshness time.Duration, gather func() ([]*dto.MetricFamily, error)) ([]*dto.MetricFamily, error) {
+	g.mutex.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mutex.Unlock()
+		scrapesCoalesced.Inc()
+		<-call.done
+		return call.families, call.err
+	}
+	call := &scrapeCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mutex.Unlock()
+
+	defer func() {
+		close(call.done)
+		forget := func() {
+			g.mutex.Lock()
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+			g.mutex.Unlock()
+		}
+		// failed collections are not reused, the next scrape retries
+		if freshness <= 0 || call.err != nil || targetDown(call.families) {
+			forget()
+		} else {
+			time.AfterFunc(freshness, forget)
+		}
+	}()
+
+	call.families, call.err = gather()
+	return call.families, call.err
+}
+
+// targetDown reports whether the collection found the target unreachable,
+// which registry.Gather does not report as an error.
+func targetDown(families []*dto.MetricFamily) bool {
+	for _, family := range families {
+		if family.GetName() != "rackserver_up" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if metric.GetGauge().GetValue() == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// WebConfig configures TLS and authentication of the exporter's own endpoints.
+type WebConfig struct {
+	TLSServerConfig *WebTLSConfig     `yaml:"tls_server_config"`
+	BasicAuthUsers  map[string]Secret `yaml:"basic_auth_users"`
+}
+
+// WebTLSConfig configures HTTPS and client certificate authentication.
+type WebTLSConfig struct {
+	CertFile     string `yaml:"cert_file"`
+	KeyFile      string `yaml:"key_file"`
+	ClientCAFile string `yaml:"client_ca_file"`
+	// ClientAuthType is one of NoClientCert, RequestClientCert,
+	// RequireAnyClientCert, VerifyClientCertIfGiven and
+	// RequireAndVerifyClientCert.
+	ClientAuthType string `yaml:"client_auth_type"`
+	MinVersion     string `yaml:"min_version"`
+}
+
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	"":                           tls.NoClientCert,
+	"NoClientCert":               tls.NoClientCert,
+	"RequestClientCert":          tls.RequestClientCert,
+	"RequireAnyClientCert":       tls.RequireAnyClientCert,
+	"VerifyClientCertIfGiven":    tls.VerifyClientCertIfGiven,
+	"RequireAndVerifyClientCert": tls.RequireAndVerifyClientCert,
+}
+
+// dummyHash is compared against for unknown users, so they take as long to
+// reject as wrong passwords.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("rackserver_exporter"), bcrypt.DefaultCost)
+
+// SafeWebConfig holds the web config, which can be reloaded while the exporter runs.
+type SafeWebConfig struct {
+	sync.RWMutex
+	C *WebConfig
+	// tlsConfig is built from C.TLSServerConfig on reload.
+	tlsConfig *tls.Config
+
+	// authCache remembers the credentials which matched a bcrypt hash, as
+	// comparing them on every scrape is expensive.
+	authCacheMutex sync.Mutex
+	authCache      map[[sha256.Size]byte]bool
+}
+
+// ReloadConfig loads and validates webConfigFile and replaces the running
+// web config with it. The running web config is kept on error.
+func (sw *SafeWebConfig) ReloadConfig(webConfigFile string) error {
+	c, tlsConfig, err := loadWebConfig(webConfigFile)
+	if err != nil {
+		return err
+	}
+	sw.setConfig(c, tlsConfig)
+	return nil
+}
+
+// loadWebConfig reads and validates webConfigFile and builds its TLS config.
+func loadWebConfig(webConfigFile string) (*WebConfig, *tls.Config, error) {
+	c := &WebConfig{}
+
+	content, err := ioutil.ReadFile(webConfigFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading web config file: %s", err)
+	}
+	if err := yaml.UnmarshalStrict(content, c); err != nil {
+		return nil, nil, fmt.Errorf("error parsing web config file: %s", err)
+	}
+
+	for user, hash := range c.BasicAuthUsers {
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return nil, nil, fmt.Errorf("invalid bcrypt hash for user %s: %s", user, err)
+		}
+	}
+
+	var tlsConfig *tls.Config
+	if c.TLSServerConfig != nil {
+		if tlsConfig, err = c.TLSServerConfig.build(); err != nil {
+			return nil, nil, fmt.Errorf("error loading tls_server_config: %s", err)
+		}
+	}
+	return c, tlsConfig, nil
+}
+
+// setConfig replaces the running web configuration with a loaded one.
+func (sw *SafeWebConfig) setConfig(c *WebConfig, tlsConfig *tls.Config) {
+	sw.Lock()
+	sw.C = c
+	sw.tlsConfig = tlsConfig
+	sw.Unlock()
+
+	sw.authCacheMutex.Lock()
+	sw.authCache = make(map[[sha256.Size]byte]bool)
+	sw.authCacheMutex.Unlock()
+}
+
+func (c *WebTLSConfig) build() (*tls.Config, error) {
+	if c.CertFile == "" || c.KeyFile == "" {
+		return nil, fmt.Errorf("cert_file and key_file are required")
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	clientAuth, ok := clientAuthTypes[c.ClientAuthType]
+	if !ok {
+		return nil, fmt.Errorf("unknown client_auth_type %s", c.ClientAuthType)
+	}
+	minVersion, ok := tlsVersions[c.MinVersion]
+	if c.MinVersion == "" {
+		minVersion, ok = tls.VersionTLS12, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown TLS version %s", c.MinVersion)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   clientAuth,
+		MinVersion:   minVersion,
+	}
+
+	if c.ClientCAFile != "" {
+		caPEM, err := ioutil.ReadFile(c.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in client_ca_file %s", c.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+	} else if clientAuth == tls.VerifyClientCertIfGiven || clientAuth == tls.RequireAndVerifyClientCert {
+		return nil, fmt.Errorf("client_ca_file is required by client_auth_type %s", c.ClientAuthType)
+	}
+
+	return tlsConfig, nil
+}
+
+// TLSEnabled reports whether the exporter serves HTTPS.
+func (sw *SafeWebConfig) TLSEnabled() bool {
+	sw.RLock()
+	defer sw.RUnlock()
+	return sw.tlsConfig != nil
+}
+
+// getConfigForClient returns the current TLS config, so reloaded certificates
+// are used by new connections.
+func (sw *SafeWebConfig) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	sw.RLock()
+	defer sw.RUnlock()
+	if sw.tlsConfig == nil {
+		return nil, fmt.Errorf("tls_server_config was removed, restart the exporter to serve plain HTTP")
+	}
+	return sw.tlsConfig, nil
+}
+
+// getCertificate returns the certificate of the current TLS config.
+func (sw *SafeWebConfig) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	sw.RLock()
+	defer sw.RUnlock()
+	if sw.tlsConfig == nil || len(sw.tlsConfig.Certificates) == 0 {
+		return nil, fmt.Errorf("tls_server_config was removed, restart the exporter to serve plain HTTP")
+	}
+	return &sw.tlsConfig.Certificates[0], nil
+}
+
+// authenticated reports whether the basic auth credentials of r are valid.
+func (sw *SafeWebConfig) authenticated(r *http.Request) bool {
+	sw.RLock()
+	users := sw.C.BasicAuthUsers
+	sw.RUnlock()
+
+	if len(users) == 0 {
+		return true
+	}
+
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+
+	hash, known := users[user]
+	cacheKey := sha256.Sum256([]byte(user + ":" + password + ":" + string(hash)))
+
+	sw.authCacheMutex.Lock()
+	cached := sw.authCache[cacheKey]
+	sw.authCacheMutex.Unlock()
+	if cached {
+		return true
+	}
+
+	if !known {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return false
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil {
+		return false
+	}
+
+	sw.authCacheMutex.Lock()
+	sw.authCache[cacheKey] = true
+	sw.authCacheMutex.Unlock()
+	return true
+}
+
+// authHandler requires basic auth on the endpoints of handler, except the
+// event receiver, which checks the subscription context instead.
+func (sw *SafeWebConfig) authHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events" && !sw.authenticated(r) {
+			w.Header().Set("WWW-Authenticate", `Basic realm="rackserver_exporter"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// listenAndServe serves handler on address, over HTTPS if the web config
+// enables it.
+func (sw *SafeWebConfig) listenAndServe(address string, handler http.Handler) error {
+	server := &http.Server{
+		Addr:    address,
+		Handler: sw.authHandler(handler),
+	}
+
+	if !sw.TLSEnabled() {
+		return server.ListenAndServe()
+	}
+
+	// ServeTLS requires a certificate in TLSConfig, GetCertificate serves the
+	// reloaded one like GetConfigForClient does the rest of the config
+	server.TLSConfig = &tls.Config{
+		GetCertificate:     sw.getCertificate,
+		GetConfigForClient: sw.getConfigForClient,
+	}
+	return server.ListenAndServeTLS("", "")
+}